@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+// benchmark runs a part count times and returns its result with the mean
+// time and allocations, and the highest peak heap across all runs.
+func benchmark(p *solver.Puzzle, part int, in *solver.Input, count int) (ret solver.Result, err error) {
+	var total solver.Stats
+	for i := 0; i < count; i++ {
+		stats := solver.Measure(func() {
+			ret, err = p.Solve(part, in)
+		})
+		if err != nil {
+			return ret, err
+		}
+		total.Duration += stats.Duration
+		total.Allocs += stats.Allocs
+		total.Bytes += stats.Bytes
+		total.PeakHeap = max(total.PeakHeap, stats.PeakHeap)
+	}
+	n := uint64(count)
+	total.Duration /= time.Duration(count)
+	total.Allocs /= n
+	total.Bytes /= n
+	ret.Duration = total.Duration
+	ret.Stats = &total
+	return ret, nil
+}
+
+// startCPUProfile starts writing a CPU profile to the supplied file and returns
+// a function to stop it.
+func startCPUProfile(file string) (func(), error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return func() {
+		pprof.StopCPUProfile()
+		_ = f.Close()
+	}, nil
+}
+
+func writeHeapProfile(file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
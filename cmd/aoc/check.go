@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func checkCommand(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to check, 0 for all")
+	root := fs.String("dir", ".", "repository root containing the day directories")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	days := solver.Days()
+	if *day != 0 {
+		days = []int{*day}
+	}
+	passed, failed := 0, 0
+	for _, d := range days {
+		p, ok := solver.Get(d)
+		if !ok {
+			return fmt.Errorf("no solution for day %d", d)
+		}
+		examples, err := solver.ReadExamples(*root, d)
+		if err != nil {
+			return err
+		}
+		for _, e := range examples {
+			b, err := os.ReadFile(filepath.Join(solver.DayDir(*root, d), e.Input))
+			if err != nil {
+				return err
+			}
+			for _, n := range []int{1, 2} {
+				if !e.Checks(p, n) {
+					continue
+				}
+				if msg := e.Check(p, n, string(b)); msg != "" {
+					failed++
+					fmt.Printf("FAIL day %02d part %d %s: %s\n", d, n, e.Input, msg)
+				} else {
+					passed++
+					fmt.Printf("ok   day %02d part %d %s\n", d, n, e.Input)
+				}
+			}
+		}
+	}
+	fmt.Printf("%d passed, %d failed\n", passed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d examples failed", failed)
+	}
+	return nil
+}
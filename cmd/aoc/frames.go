@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gotwarlost/aoc2024/render"
+)
+
+// frameFlags are the flags that export the frames of a simulation.
+type frameFlags struct {
+	gifFile string
+	pngDir  string
+	cell    int
+	palette string
+	delay   int
+	every   int
+}
+
+func (f *frameFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.gifFile, "gif", "", "write the frames of the simulation to this animated GIF")
+	fs.StringVar(&f.pngDir, "png", "", "write the frames of the simulation as PNG files to this directory")
+	fs.IntVar(&f.cell, "cell", 4, "size of a grid cell in pixels")
+	fs.StringVar(&f.palette, "palette", "", "comma separated rrggbb colours replacing the default palette, blank entries keep the default")
+	fs.IntVar(&f.delay, "delay", 5, "delay between GIF frames in 100ths of a second")
+	fs.IntVar(&f.every, "every", 1, "only keep every nth frame")
+}
+
+func (f *frameFlags) enabled() bool {
+	return f.gifFile != "" || f.pngDir != ""
+}
+
+// sink returns the sink for frames and a function to call once the simulation
+// has run, or a nil sink if no frames are wanted.
+func (f *frameFlags) sink() (render.Sink, func() error, error) {
+	if !f.enabled() {
+		return nil, func() error { return nil }, nil
+	}
+	if f.gifFile != "" && f.pngDir != "" {
+		return nil, nil, errors.New("only one of -gif and -png can be supplied")
+	}
+	palette, err := render.ParsePalette(f.palette)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts := render.Options{CellSize: f.cell, Palette: palette, Delay: f.delay, Every: f.every}
+	if f.pngDir != "" {
+		s := render.NewPNGs(f.pngDir, opts)
+		return s, func() error {
+			if s.Len() == 0 {
+				return errors.New("the solution does not render any frames")
+			}
+			return s.Err()
+		}, nil
+	}
+	s := render.NewGIF(opts)
+	return s, func() error {
+		if s.Len() == 0 {
+			return errors.New("the solution does not render any frames")
+		}
+		out, err := os.Create(f.gifFile)
+		if err != nil {
+			return err
+		}
+		if err := s.Encode(out); err != nil {
+			_ = out.Close()
+			return fmt.Errorf("write %s: %v", f.gifFile, err)
+		}
+		return out.Close()
+	}, nil
+}
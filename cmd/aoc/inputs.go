@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gotwarlost/aoc2024/inputs"
+)
+
+// sourceFlags are the flags that control where puzzle inputs come from.
+type sourceFlags struct {
+	cacheDir string
+	baseURL  string
+	session  string
+	interval time.Duration
+	offline  bool
+}
+
+func (s *sourceFlags) registerCache(fs *flag.FlagSet) {
+	fs.StringVar(&s.cacheDir, "cache", "", "input cache directory, defaults to aoc2024 under the user cache directory")
+}
+
+func (s *sourceFlags) register(fs *flag.FlagSet) {
+	s.registerCache(fs)
+	fs.StringVar(&s.baseURL, "url", inputs.DefaultBaseURL, "base URL of the puzzle site")
+	fs.StringVar(&s.session, "session", os.Getenv("AOC_SESSION"), "session cookie for the puzzle site, defaults to $AOC_SESSION")
+	fs.DurationVar(&s.interval, "interval", inputs.DefaultInterval, "minimum time between requests to the puzzle site")
+	fs.BoolVar(&s.offline, "offline", false, "never fetch inputs from the puzzle site")
+}
+
+func (s *sourceFlags) cache() (*inputs.Cache, error) {
+	dir := s.cacheDir
+	if dir == "" {
+		var err error
+		if dir, err = inputs.DefaultDir(); err != nil {
+			return nil, err
+		}
+	}
+	return &inputs.Cache{Dir: dir}, nil
+}
+
+func (s *sourceFlags) fetcher() *inputs.Fetcher {
+	return &inputs.Fetcher{BaseURL: s.baseURL, Session: s.session, Interval: s.interval}
+}
+
+func (s *sourceFlags) loader() (*inputs.Loader, error) {
+	c, err := s.cache()
+	if err != nil {
+		return nil, err
+	}
+	l := &inputs.Loader{Cache: c}
+	if !s.offline {
+		l.Fetcher = s.fetcher()
+	}
+	return l, nil
+}
+
+func fetchCommand(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to fetch, 0 for every day not already cached")
+	force := fs.Bool("force", false, "fetch even if the input is already cached")
+	var src sourceFlags
+	src.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	c, err := src.cache()
+	if err != nil {
+		return err
+	}
+	f := src.fetcher()
+
+	days := []int{*day}
+	if *day == 0 {
+		days = nil
+		for d := 1; d <= 25; d++ {
+			days = append(days, d)
+		}
+	}
+	for _, d := range days {
+		if !*force {
+			_, ok, err := c.Get(d)
+			if err != nil {
+				return err
+			}
+			if ok {
+				continue
+			}
+		}
+		text, err := f.Fetch(context.Background(), d)
+		if err != nil {
+			return err
+		}
+		if err := c.Put(d, text); err != nil {
+			return err
+		}
+		fmt.Printf("fetched day %02d\n", d)
+	}
+	return nil
+}
+
+func importCommand(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	root := fs.String("dir", ".", "repository root containing the day directories")
+	var src sourceFlags
+	src.registerCache(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	c, err := src.cache()
+	if err != nil {
+		return err
+	}
+	days, err := c.Import(*root)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("imported %d inputs into %s\n", len(days), c.Dir)
+	return nil
+}
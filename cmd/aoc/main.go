@@ -0,0 +1,64 @@
+// Command aoc runs the puzzle solutions for any day against any input.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	_ "github.com/gotwarlost/aoc2024/days"
+	"github.com/gotwarlost/aoc2024/scan"
+)
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]command{
+	"run":    {usage: "run a day against its puzzle input, a file or stdin", run: runCommand},
+	"check":  {usage: "check the answers for the examples of every day", run: checkCommand},
+	"fetch":  {usage: "download puzzle inputs into the input cache", run: fetchCommand},
+	"import": {usage: "copy the input.txt files of the day directories into the input cache", run: importCommand},
+	"serve":  {usage: "start a local web dashboard to run the solutions and view their renderings", run: serveCommand},
+}
+
+func usage() {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	fmt.Fprintln(&b, "usage: aoc <command> [flags]")
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "commands:")
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %-8s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprint(os.Stderr, b.String())
+}
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:]); err != nil {
+		log.Println(err)
+		var pe *scan.Error
+		if errors.As(err, &pe) {
+			fmt.Fprint(os.Stderr, pe.Snippet())
+		}
+		os.Exit(1)
+	}
+}
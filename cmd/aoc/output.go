@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+// printer writes results in one of the supported formats.
+type printer struct {
+	format  string
+	w       io.Writer
+	started bool
+}
+
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	switch format {
+	case "text", "json", "tsv":
+		return &printer{format: format, w: w}, nil
+	default:
+		return nil, fmt.Errorf("invalid format %q, must be one of text, json or tsv", format)
+	}
+}
+
+func diagnosticsText(d map[string]any) string {
+	var keys []string
+	for k := range d {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var strs []string
+	for _, k := range keys {
+		strs = append(strs, fmt.Sprintf("%s=%v", k, d[k]))
+	}
+	return strings.Join(strs, " ")
+}
+
+func (p *printer) print(r solver.Result) error {
+	switch p.format {
+	case "json":
+		// one object per line
+		return json.NewEncoder(p.w).Encode(r)
+	case "tsv":
+		if !p.started {
+			p.started = true
+			if _, err := fmt.Fprintln(p.w, "day\tpart\tanswer\tduration_ns\tallocs\tbytes\tpeak_heap\tdiagnostics"); err != nil {
+				return err
+			}
+		}
+		var stats string
+		if r.Stats != nil {
+			stats = fmt.Sprintf("%d\t%d\t%d", r.Stats.Allocs, r.Stats.Bytes, r.Stats.PeakHeap)
+		} else {
+			stats = "\t\t"
+		}
+		var diag string
+		if len(r.Diagnostics) > 0 {
+			b, err := json.Marshal(r.Diagnostics)
+			if err != nil {
+				return err
+			}
+			diag = string(b)
+		}
+		_, err := fmt.Fprintf(p.w, "%d\t%d\t%v\t%d\t%s\t%s\n", r.Day, r.Part, r.Answer, r.Duration.Nanoseconds(), stats, diag)
+		return err
+	default:
+		line := fmt.Sprintf("day %d part %d: %v", r.Day, r.Part, r.Answer)
+		if r.Stats != nil {
+			line += "\t" + r.Stats.String()
+		}
+		if len(r.Diagnostics) > 0 {
+			line += "\t" + diagnosticsText(r.Diagnostics)
+		}
+		_, err := fmt.Fprintln(p.w, line)
+		return err
+	}
+}
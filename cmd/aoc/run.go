@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/inputs"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+// params collects repeated key=value flags.
+type params map[string]string
+
+func (p params) String() string {
+	var strs []string
+	for k, v := range p {
+		strs = append(strs, k+"="+v)
+	}
+	return strings.Join(strs, ",")
+}
+
+func (p params) Set(s string) error {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("param %q is not of the form key=value", s)
+	}
+	p[k] = v
+	return nil
+}
+
+// readInput returns the contents of the supplied file, stdin if the file is "-"
+// or the input found by the loader if it is blank.
+func readInput(l *inputs.Loader, day int, file string) (string, error) {
+	switch file {
+	case "":
+		return l.Load(context.Background(), day)
+	case "-":
+		b, err := io.ReadAll(os.Stdin)
+		return string(b), err
+	default:
+		b, err := os.ReadFile(file)
+		return string(b), err
+	}
+}
+
+// streamInput returns the file that a puzzle that streams its input reads, or a
+// blank string for other puzzles and the cached, embedded or fetched input. Stdin
+// is copied to a temporary file so that each part can read it, and the returned
+// function removes it.
+func streamInput(p *solver.Puzzle, file string) (string, func(), error) {
+	if !p.Stream || file == "" {
+		return "", func() {}, nil
+	}
+	if file != "-" {
+		return file, func() {}, nil
+	}
+	f, err := os.CreateTemp("", fmt.Sprintf("aoc-dec%02d-", p.Day))
+	if err != nil {
+		return "", nil, err
+	}
+	remove := func() { _ = os.Remove(f.Name()) }
+	if _, err := io.Copy(f, os.Stdin); err != nil {
+		_ = f.Close()
+		remove()
+		return "", nil, err
+	}
+	if err := f.Close(); err != nil {
+		remove()
+		return "", nil, err
+	}
+	return f.Name(), remove, nil
+}
+
+// writeMap writes the map recorded by a part as JSON to the supplied file.
+func writeMap(file string, ret solver.Result) error {
+	if ret.Overlay == nil {
+		return errors.New("the solution does not record a map")
+	}
+	b, err := json.Marshal(ret.Overlay)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, b, 0o644)
+}
+
+func runCommand(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	day := fs.Int("day", 0, "day to run, 0 for every day")
+	part := fs.Int("part", 0, "part to run, 0 for both")
+	file := fs.String("input", "", "input file, - for stdin, defaults to the cached, embedded or fetched input")
+	ps := params{}
+	fs.Var(ps, "p", "solver parameter as key=value, may be repeated")
+	bench := fs.Bool("bench", false, "report the time and memory used by each part")
+	count := fs.Int("count", 1, "number of times to run each part when benchmarking")
+	cpuProfile := fs.String("cpuprofile", "", "write a CPU profile to this file")
+	memProfile := fs.String("memprofile", "", "write a heap profile to this file")
+	format := fs.String("format", "text", "output format, one of text, json or tsv")
+	verbosity := fs.Int("v", 0, "verbosity of debug output written to stderr, 1 for debug, 2 to trace")
+	var src sourceFlags
+	src.register(fs)
+	var frames frameFlags
+	frames.register(fs)
+	mapFile := fs.String("map", "", "write the map recorded by the solution as JSON to this file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	loader, err := src.loader()
+	if err != nil {
+		return err
+	}
+	out, err := newPrinter(*format, os.Stdout)
+	if err != nil {
+		return err
+	}
+	if *count < 1 {
+		return fmt.Errorf("invalid count %d", *count)
+	}
+
+	days := solver.Days()
+	if *day != 0 {
+		days = []int{*day}
+	} else if *file != "" {
+		return fmt.Errorf("an input file can only be supplied for a single day")
+	}
+	parts := []int{1, 2}
+	switch *part {
+	case 0:
+	case 1, 2:
+		parts = []int{*part}
+	default:
+		return fmt.Errorf("invalid part %d", *part)
+	}
+
+	if frames.enabled() && (*day == 0 || *part == 0 || *bench) {
+		return fmt.Errorf("frames can only be rendered for a single day and part without benchmarking")
+	}
+	if *mapFile != "" && (*day == 0 || *part == 0) {
+		return fmt.Errorf("a map can only be written for a single day and part")
+	}
+	sink, finish, err := frames.sink()
+	if err != nil {
+		return err
+	}
+
+	if *cpuProfile != "" {
+		stop, err := startCPUProfile(*cpuProfile)
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
+	for _, d := range days {
+		p, ok := solver.Get(d)
+		if !ok {
+			return fmt.Errorf("no solution for day %d", d)
+		}
+		stream, remove, err := streamInput(p, *file)
+		if err != nil {
+			return err
+		}
+		defer remove()
+		var text string
+		if stream == "" {
+			if text, err = readInput(loader, d, *file); err != nil {
+				return err
+			}
+		}
+		for _, n := range parts {
+			if *part == 0 && p.Part(n) == nil {
+				continue
+			}
+			in := &solver.Input{Text: text, File: stream, Params: ps, Verbosity: *verbosity, Frames: sink}
+			var ret solver.Result
+			if *bench {
+				ret, err = benchmark(p, n, in, *count)
+			} else {
+				ret, err = p.Solve(n, in)
+			}
+			if err != nil {
+				return fmt.Errorf("day %d part %d: %w", d, n, err)
+			}
+			if err := out.print(ret); err != nil {
+				return err
+			}
+			if *mapFile != "" {
+				if err := writeMap(*mapFile, ret); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if err := finish(); err != nil {
+		return err
+	}
+	if *memProfile != "" {
+		return writeHeapProfile(*memProfile)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"image/png"
+	"io/fs"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/inputs"
+	"github.com/gotwarlost/aoc2024/render"
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+//go:embed static
+var static embed.FS
+
+// maxInputSize is the largest request accepted by the run endpoint.
+const maxInputSize = 10 << 20
+
+// maxImageSize is the size in pixels of the longest side of a rendering.
+const maxImageSize = 640
+
+// dayInfo describes a day in the list of days.
+type dayInfo struct {
+	Day      int   `json:"day"`
+	Parts    []int `json:"parts"`
+	HasInput bool  `json:"has_input"` // true if the puzzle input is available without fetching it
+}
+
+// runRequest asks for one or both parts of a day to be run.
+type runRequest struct {
+	Day    int               `json:"day"`
+	Part   int               `json:"part"`  // 0 for both parts
+	Input  string            `json:"input"` // blank for the puzzle input
+	Params map[string]string `json:"params"`
+}
+
+// runResult is the result of a part along with the rendering of its final state.
+type runResult struct {
+	solver.Result
+	Image string `json:"image,omitempty"` // PNG data URL
+}
+
+type runResponse struct {
+	Results []runResult `json:"results"`
+	Error   string      `json:"error,omitempty"`
+	Snippet string      `json:"snippet,omitempty"` // the offending input for parse errors
+}
+
+type server struct {
+	loader *inputs.Loader
+}
+
+func (s *server) days(w http.ResponseWriter, r *http.Request) {
+	var ret []dayInfo
+	for _, d := range solver.Days() {
+		p, _ := solver.Get(d)
+		info := dayInfo{Day: d, HasInput: s.loader.Available(d)}
+		for _, n := range []int{1, 2} {
+			if p.Part(n) != nil {
+				info.Parts = append(info.Parts, n)
+			}
+		}
+		ret = append(ret, info)
+	}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+// rendering returns the last frame rendered by a part as a PNG data URL, or a blank
+// string if it didn't render any.
+func rendering(last *render.Last) (string, error) {
+	rows, cols := last.Size()
+	if rows == 0 {
+		return "", nil
+	}
+	cell := min(max(1, maxImageSize/max(rows, cols)), 16)
+	var b bytes.Buffer
+	if err := png.Encode(&b, last.Image(render.Options{CellSize: cell})); err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(b.Bytes()), nil
+}
+
+func (s *server) run(w http.ResponseWriter, r *http.Request) {
+	if mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mt != "application/json" {
+		writeJSON(w, http.StatusUnsupportedMediaType, runResponse{Error: "the request must be sent as application/json"})
+		return
+	}
+	var req runRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxInputSize)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, runResponse{Error: err.Error()})
+		return
+	}
+	p, ok := solver.Get(req.Day)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, runResponse{Error: fmt.Sprintf("no solution for day %d", req.Day)})
+		return
+	}
+	for _, name := range p.Files {
+		if _, ok := req.Params[name]; ok {
+			writeJSON(w, http.StatusBadRequest, runResponse{Error: fmt.Sprintf("param %s names a file, which the dashboard does not read", name)})
+			return
+		}
+	}
+	parts := []int{1, 2}
+	if req.Part != 0 {
+		parts = []int{req.Part}
+	}
+	text := req.Input
+	if text == "" {
+		var err error
+		if text, err = s.loader.Load(r.Context(), req.Day); err != nil {
+			writeJSON(w, http.StatusOK, runResponse{Error: err.Error()})
+			return
+		}
+	}
+
+	var res runResponse
+	for _, n := range parts {
+		if req.Part == 0 && p.Part(n) == nil {
+			continue
+		}
+		last := &render.Last{}
+		in := &solver.Input{Text: text, Params: req.Params, Frames: last}
+		ret, err := p.Solve(n, in)
+		if err != nil {
+			res.Error = fmt.Sprintf("part %d: %v", n, err)
+			var pe *scan.Error
+			if errors.As(err, &pe) {
+				res.Snippet = pe.Snippet()
+			}
+			break
+		}
+		img, err := rendering(last)
+		if err != nil {
+			res.Error = err.Error()
+			break
+		}
+		res.Results = append(res.Results, runResult{Result: ret, Image: img})
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Println("write response:", err)
+	}
+}
+
+// loopback returns true if the host, with or without a port, is localhost or a
+// loopback address.
+func loopback(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// local rejects requests whose Host or Origin is not a loopback address, so that
+// other web pages, including those whose names have been rebound to this machine,
+// cannot run the solutions.
+func local(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !loopback(r.Host) {
+			http.Error(w, fmt.Sprintf("host %s is not a loopback address", r.Host), http.StatusForbidden)
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" {
+			u, err := url.Parse(origin)
+			if err != nil || !loopback(u.Host) {
+				http.Error(w, fmt.Sprintf("origin %s is not a loopback address", origin), http.StatusForbidden)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *server) handler() http.Handler {
+	assets, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(assets))
+	mux.HandleFunc("GET /api/days", s.days)
+	mux.HandleFunc("POST /api/run", s.run)
+	return local(mux)
+}
+
+func serveCommand(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:8024", "address to listen on")
+	var src sourceFlags
+	src.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	loader, err := src.loader()
+	if err != nil {
+		return err
+	}
+	s := &server{loader: loader}
+	log.Printf("serving on http://%s", *addr)
+	return http.ListenAndServe(*addr, s.handler())
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gotwarlost/aoc2024/inputs"
+)
+
+// post sends a run request to the dashboard with the supplied host, content type
+// and origin, a blank origin being left out.
+func post(t *testing.T, host, contentType, origin, body string) (int, runResponse) {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodPost, "/api/run", strings.NewReader(body))
+	r.Host = host
+	r.Header.Set("Content-Type", contentType)
+	if origin != "" {
+		r.Header.Set("Origin", origin)
+	}
+	w := httptest.NewRecorder()
+	s := &server{loader: &inputs.Loader{}}
+	s.handler().ServeHTTP(w, r)
+	var res runResponse
+	if w.Header().Get("Content-Type") == "application/json" {
+		if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return w.Code, res
+}
+
+func TestRun(t *testing.T) {
+	const day1 = `{"day":1,"part":1,"input":"3 4\n4 3\n"}`
+	tests := []struct {
+		name        string
+		host        string
+		contentType string
+		origin      string
+		body        string
+		status      int
+		error       string
+	}{
+		{"localhost", "localhost:8024", "application/json", "", day1, http.StatusOK, ""},
+		{"loopback address", "127.0.0.1:8024", "application/json; charset=utf-8", "http://127.0.0.1:8024", day1, http.StatusOK, ""},
+		{"ipv6 loopback", "[::1]:8024", "application/json", "http://[::1]:8024", day1, http.StatusOK, ""},
+		{"plain text", "localhost:8024", "text/plain", "", day1, http.StatusUnsupportedMediaType, "the request must be sent as application/json"},
+		{"form", "localhost:8024", "application/x-www-form-urlencoded", "", day1, http.StatusUnsupportedMediaType, "the request must be sent as application/json"},
+		{"rebound host", "evil.example:8024", "application/json", "", day1, http.StatusForbidden, ""},
+		{"other origin", "localhost:8024", "application/json", "https://evil.example", day1, http.StatusForbidden, ""},
+		{"policy file", "localhost:8024", "application/json", "", `{"day":2,"input":"1 2\n","params":{"policy":"/etc/passwd"}}`,
+			http.StatusBadRequest, "param policy names a file, which the dashboard does not read"},
+		{"templates file", "localhost:8024", "application/json", "", `{"day":4,"input":"XMAS\n","params":{"templates":"/etc/passwd"}}`,
+			http.StatusBadRequest, "param templates names a file, which the dashboard does not read"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, res := post(t, test.host, test.contentType, test.origin, test.body)
+			if status != test.status {
+				t.Errorf("got status %d, want %d", status, test.status)
+			}
+			if res.Error != test.error {
+				t.Errorf("got error %q, want %q", res.Error, test.error)
+			}
+			if test.status == http.StatusOK && (len(res.Results) != 1 || res.Results[0].Answer != float64(0)) {
+				t.Errorf("got results %+v, want an answer of 0", res.Results)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+'use strict';
+
+const state = { days: [], day: null };
+
+const $ = (id) => document.getElementById(id);
+
+function el(tag, props, ...children) {
+  const e = document.createElement(tag);
+  Object.assign(e, props);
+  e.append(...children);
+  return e;
+}
+
+function formatDuration(ns) {
+  if (ns < 1e3) return ns + 'ns';
+  if (ns < 1e6) return (ns / 1e3).toFixed(1) + 'µs';
+  if (ns < 1e9) return (ns / 1e6).toFixed(1) + 'ms';
+  return (ns / 1e9).toFixed(2) + 's';
+}
+
+function parseParams(text) {
+  const ret = {};
+  for (const line of text.split('\n')) {
+    const s = line.trim();
+    if (s === '') continue;
+    const i = s.indexOf('=');
+    if (i < 0) throw new Error(`invalid parameter "${s}", want key=value`);
+    ret[s.slice(0, i).trim()] = s.slice(i + 1).trim();
+  }
+  return ret;
+}
+
+async function loadDays() {
+  const resp = await fetch('api/days');
+  state.days = await resp.json();
+  const list = $('days');
+  for (const d of state.days) {
+    const a = el('a', { href: '#' + d.day, textContent: `Day ${d.day}` });
+    if (!d.has_input) {
+      a.classList.add('missing');
+      a.title = 'the puzzle input will be fetched';
+    }
+    list.append(el('li', {}, a));
+  }
+  selectDay();
+}
+
+function selectDay() {
+  const n = parseInt(location.hash.slice(1), 10);
+  const d = state.days.find((d) => d.day === n);
+  for (const a of $('days').querySelectorAll('a')) {
+    a.classList.toggle('selected', a.hash === location.hash);
+  }
+  if (!d) {
+    $('day').hidden = true;
+    return;
+  }
+  if (state.day !== d) {
+    $('input').value = '';
+    $('params').value = '';
+    $('output').replaceChildren();
+  }
+  state.day = d;
+  $('title').textContent = `Day ${d.day}`;
+  for (const b of document.querySelectorAll('button[data-part]')) {
+    const part = parseInt(b.dataset.part, 10);
+    b.disabled = part !== 0 && !d.parts.includes(part);
+  }
+  $('day').hidden = false;
+}
+
+function showResult(r) {
+  const div = el('div', { className: 'result' },
+    el('h3', { textContent: `Part ${r.part}` }),
+    el('p', {}, 'Answer: ', el('span', { className: 'answer', textContent: String(r.answer) }),
+      ` in ${formatDuration(r.duration_ns)}`));
+  if (r.diagnostics) {
+    div.append(el('pre', { textContent: JSON.stringify(r.diagnostics, null, 2) }));
+  }
+  if (r.image) {
+    div.append(el('img', { src: r.image, alt: `rendering of part ${r.part}` }));
+  }
+  $('output').append(div);
+}
+
+function showError(message, snippet) {
+  $('output').append(el('p', { className: 'error', textContent: message }));
+  if (snippet) {
+    $('output').append(el('pre', { className: 'error', textContent: snippet }));
+  }
+}
+
+async function run(part) {
+  const out = $('output');
+  out.replaceChildren(el('p', { textContent: 'Running…' }));
+  const buttons = document.querySelectorAll('button[data-part]');
+  buttons.forEach((b) => { b.dataset.wasDisabled = b.disabled; b.disabled = true; });
+  try {
+    const req = {
+      day: state.day.day,
+      part: part,
+      input: $('input').value,
+      params: parseParams($('params').value),
+    };
+    const resp = await fetch('api/run', {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/json' },
+      body: JSON.stringify(req),
+    });
+    const res = await resp.json();
+    out.replaceChildren();
+    for (const r of res.results || []) {
+      showResult(r);
+    }
+    if (res.error) {
+      showError(res.error, res.snippet);
+    }
+  } catch (e) {
+    out.replaceChildren();
+    showError(e.message);
+  } finally {
+    buttons.forEach((b) => { b.disabled = b.dataset.wasDisabled === 'true'; });
+  }
+}
+
+$('file').addEventListener('change', (e) => {
+  const f = e.target.files[0];
+  if (!f) return;
+  const reader = new FileReader();
+  reader.onload = () => { $('input').value = reader.result; };
+  reader.readAsText(f);
+  e.target.value = '';
+});
+
+for (const b of document.querySelectorAll('button[data-part]')) {
+  b.addEventListener('click', () => run(parseInt(b.dataset.part, 10)));
+}
+
+window.addEventListener('hashchange', selectDay);
+loadDays();
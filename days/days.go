@@ -0,0 +1,31 @@
+// Package days imports the solution of every day so that it registers itself
+// with the solver.
+package days
+
+import (
+	_ "github.com/gotwarlost/aoc2024/dec01"
+	_ "github.com/gotwarlost/aoc2024/dec02"
+	_ "github.com/gotwarlost/aoc2024/dec03"
+	_ "github.com/gotwarlost/aoc2024/dec04"
+	_ "github.com/gotwarlost/aoc2024/dec05"
+	_ "github.com/gotwarlost/aoc2024/dec06"
+	_ "github.com/gotwarlost/aoc2024/dec07"
+	_ "github.com/gotwarlost/aoc2024/dec08"
+	_ "github.com/gotwarlost/aoc2024/dec09"
+	_ "github.com/gotwarlost/aoc2024/dec10"
+	_ "github.com/gotwarlost/aoc2024/dec11"
+	_ "github.com/gotwarlost/aoc2024/dec12"
+	_ "github.com/gotwarlost/aoc2024/dec13"
+	_ "github.com/gotwarlost/aoc2024/dec14"
+	_ "github.com/gotwarlost/aoc2024/dec15"
+	_ "github.com/gotwarlost/aoc2024/dec16"
+	_ "github.com/gotwarlost/aoc2024/dec17"
+	_ "github.com/gotwarlost/aoc2024/dec18"
+	_ "github.com/gotwarlost/aoc2024/dec19"
+	_ "github.com/gotwarlost/aoc2024/dec20"
+	_ "github.com/gotwarlost/aoc2024/dec21"
+	_ "github.com/gotwarlost/aoc2024/dec22"
+	_ "github.com/gotwarlost/aoc2024/dec23"
+	_ "github.com/gotwarlost/aoc2024/dec24"
+	_ "github.com/gotwarlost/aoc2024/dec25"
+)
@@ -0,0 +1,257 @@
+package dec01
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+// memoryLimit is the default number of locations of each list held in memory
+// before sorted runs are spilled to temporary files.
+const memoryLimit = 1 << 22
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 1, Part1: part1, Part2: part2, Stream: true})
+}
+
+// parse reads the input line by line, calling fn with the locations on each line,
+// one per list, so that the lists need not be held in memory. Every line must have
+// the same number of locations and there must be at least two lists. The slice
+// passed to fn is reused for the next line.
+func parse(in *solver.Input, fn func(ids []int) error) (lists int, err error) {
+	r, err := in.Open()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = r.Close() }()
+	var ids []int
+	err = scan.Read(r, func(line scan.Line) error {
+		s := line.Scan()
+		s.Spaces()
+		ids = ids[:0]
+		for !s.Done() {
+			n, err := s.Int()
+			if err != nil {
+				return err
+			}
+			ids = append(ids, n)
+			if !s.Spaces() && !s.Done() {
+				return s.Err("space")
+			}
+		}
+		switch {
+		case len(ids) == 0:
+			return nil
+		case lists == 0 && len(ids) < 2:
+			return line.Error("at least two locations")
+		case lists == 0:
+			lists = len(ids)
+		case len(ids) != lists:
+			return line.Error(fmt.Sprintf("%d locations", lists))
+		}
+		return fn(ids)
+	})
+	return lists, err
+}
+
+// matrix holds a value for every pair of lists.
+type matrix [][]int
+
+func newMatrix(n int) matrix {
+	m := make(matrix, n)
+	for i := range m {
+		m[i] = make([]int, n)
+	}
+	return m
+}
+
+// answer is the value for the first and last lists, which are the two lists of
+// the puzzle.
+func (m matrix) answer() int {
+	return m[0][len(m)-1]
+}
+
+// distances sorts every list and sums the distances between the locations of the
+// same rank for every pair of lists.
+func distances(in *solver.Input) (matrix, error) {
+	limit, err := in.Int("memory", memoryLimit)
+	if err != nil {
+		return nil, err
+	}
+	var sorters []*sorter
+	defer func() {
+		for _, s := range sorters {
+			s.close()
+		}
+	}()
+	n, err := parse(in, func(ids []int) error {
+		if sorters == nil {
+			for range ids {
+				sorters = append(sorters, &sorter{limit: limit})
+			}
+		}
+		for i, id := range ids {
+			if err := sorters[i].add(id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return newMatrix(2), nil
+	}
+	runs := 0
+	mergers := make([]*merger, n)
+	for i, s := range sorters {
+		if mergers[i], err = s.sorted(); err != nil {
+			return nil, err
+		}
+		// sorted spills what is left in memory, so the runs are counted after it
+		runs += len(s.runs)
+	}
+	if runs > 0 {
+		in.Diagnose("runs", runs)
+	}
+	ret := newMatrix(n)
+	ids := make([]int, n)
+	for {
+		more := true
+		for i, m := range mergers {
+			var ok bool
+			if ids[i], ok = m.next(); !ok {
+				more = false
+			}
+		}
+		if !more {
+			break
+		}
+		for i := range ids {
+			for j := i + 1; j < n; j++ {
+				diff := ids[i] - ids[j]
+				if diff < 0 {
+					diff = -diff
+				}
+				ret[i][j] += diff
+				ret[j][i] += diff
+			}
+		}
+	}
+	for _, m := range mergers {
+		if err := m.err(); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+func part1(in *solver.Input) (any, error) {
+	m, err := distances(in)
+	if err != nil {
+		return nil, err
+	}
+	if len(m) > 2 {
+		in.Diagnose("distances", m)
+	}
+	return m.answer(), nil
+}
+
+// counts returns the number of times each location appears in each list.
+func counts(in *solver.Input) ([]map[int]int, error) {
+	var ret []map[int]int
+	_, err := parse(in, func(ids []int) error {
+		if ret == nil {
+			for range ids {
+				ret = append(ret, map[int]int{})
+			}
+		}
+		for i, id := range ids {
+			ret[i][id]++
+		}
+		return nil
+	})
+	if ret == nil {
+		ret = []map[int]int{{}, {}}
+	}
+	return ret, err
+}
+
+// similarities returns the similarity score of every pair of lists, which sums
+// every location multiplied by the number of times it appears in both lists.
+func similarities(lists []map[int]int) matrix {
+	ret := newMatrix(len(lists))
+	for i, a := range lists {
+		for j := i + 1; j < len(lists); j++ {
+			b := lists[j]
+			score := 0
+			for id, count := range a {
+				score += id * count * b[id]
+			}
+			ret[i][j] = score
+			ret[j][i] = score
+		}
+	}
+	return ret
+}
+
+// consensus returns the locations that appear in every list and, for each list,
+// the locations that appear only in that list.
+func consensus(lists []map[int]int) (shared []int, unique [][]int) {
+	unique = make([][]int, len(lists))
+	seen := map[int]int{} // number of lists each location appears in
+	for _, l := range lists {
+		for id := range l {
+			seen[id]++
+		}
+	}
+	for id, n := range seen {
+		if n == len(lists) {
+			shared = append(shared, id)
+		}
+	}
+	for i, l := range lists {
+		for id := range l {
+			if seen[id] == 1 {
+				unique[i] = append(unique[i], id)
+			}
+		}
+		slices.Sort(unique[i])
+	}
+	slices.Sort(shared)
+	return shared, unique
+}
+
+func join(ids []int) string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = strconv.Itoa(id)
+	}
+	return strings.Join(strs, " ")
+}
+
+func part2(in *solver.Input) (any, error) {
+	lists, err := counts(in)
+	if err != nil {
+		return nil, err
+	}
+	m := similarities(lists)
+	if len(m) > 2 {
+		in.Diagnose("similarities", m)
+		shared, unique := consensus(lists)
+		sizes := make([]int, len(unique))
+		in.Debugf("shared by every list: %s", join(shared))
+		for i, ids := range unique {
+			sizes[i] = len(ids)
+			in.Debugf("only in list %d: %s", i+1, join(ids))
+		}
+		in.Diagnose("shared", len(shared))
+		in.Diagnose("unique", sizes)
+	}
+	return m.answer(), nil
+}
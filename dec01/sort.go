@@ -0,0 +1,182 @@
+package dec01
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+)
+
+// sorter sorts a list of numbers that may not fit in memory. Once more than limit
+// numbers have been added, they are sorted and spilled to a temporary file as a
+// run, and the runs are merged when the sorted list is read.
+type sorter struct {
+	limit int
+	buf   []int
+	dir   string   // temporary directory for the runs, created by the first spill
+	runs  []string // files of the sorted runs
+	files []*os.File
+}
+
+func (s *sorter) add(n int) error {
+	s.buf = append(s.buf, n)
+	if s.limit > 0 && len(s.buf) >= s.limit {
+		return s.spill()
+	}
+	return nil
+}
+
+// spill writes the buffered numbers to a new run.
+func (s *sorter) spill() error {
+	if s.dir == "" {
+		dir, err := os.MkdirTemp("", "aoc-dec01-")
+		if err != nil {
+			return err
+		}
+		s.dir = dir
+	}
+	slices.Sort(s.buf)
+	file := filepath.Join(s.dir, "run-"+strconv.Itoa(len(s.runs)))
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	var b [binary.MaxVarintLen64]byte
+	for _, n := range s.buf {
+		if _, err := w.Write(b[:binary.PutVarint(b[:], int64(n))]); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	s.runs = append(s.runs, file)
+	s.buf = s.buf[:0]
+	return nil
+}
+
+// sorted returns the numbers added in ascending order.
+func (s *sorter) sorted() (*merger, error) {
+	m := &merger{}
+	if len(s.runs) == 0 {
+		slices.Sort(s.buf)
+		m.push(&run{buf: s.buf})
+		return m, nil
+	}
+	if len(s.buf) > 0 {
+		if err := s.spill(); err != nil {
+			return nil, err
+		}
+	}
+	for _, file := range s.runs {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		s.files = append(s.files, f)
+		m.push(&run{r: bufio.NewReader(f)})
+	}
+	return m, nil
+}
+
+// close removes the runs.
+func (s *sorter) close() {
+	for _, f := range s.files {
+		_ = f.Close()
+	}
+	if s.dir != "" {
+		_ = os.RemoveAll(s.dir)
+	}
+}
+
+// run is a sorted list of numbers read either from memory or from a file.
+type run struct {
+	buf  []int
+	r    *bufio.Reader
+	head int // the next number
+}
+
+// advance moves to the next number and returns false at the end of the run.
+func (r *run) advance() (bool, error) {
+	if r.r == nil {
+		if len(r.buf) == 0 {
+			return false, nil
+		}
+		r.head, r.buf = r.buf[0], r.buf[1:]
+		return true, nil
+	}
+	n, err := binary.ReadVarint(r.r)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		return false, err
+	}
+	r.head = int(n)
+	return true, nil
+}
+
+// merger merges sorted runs using a heap ordered by the next number of each run.
+type merger struct {
+	runs []*run
+	e    error
+}
+
+func (m *merger) Len() int           { return len(m.runs) }
+func (m *merger) Less(i, j int) bool { return m.runs[i].head < m.runs[j].head }
+func (m *merger) Swap(i, j int)      { m.runs[i], m.runs[j] = m.runs[j], m.runs[i] }
+func (m *merger) Push(x any)         { m.runs = append(m.runs, x.(*run)) }
+
+func (m *merger) Pop() any {
+	r := m.runs[len(m.runs)-1]
+	m.runs = m.runs[:len(m.runs)-1]
+	return r
+}
+
+// push adds a run unless it is empty.
+func (m *merger) push(r *run) {
+	ok, err := r.advance()
+	if err != nil {
+		m.e = err
+		return
+	}
+	if ok {
+		heap.Push(m, r)
+	}
+}
+
+// next returns the smallest remaining number and false when there are none left
+// or reading a run failed.
+func (m *merger) next() (int, bool) {
+	if m.e != nil || len(m.runs) == 0 {
+		return 0, false
+	}
+	r := m.runs[0]
+	n := r.head
+	ok, err := r.advance()
+	switch {
+	case err != nil:
+		m.e = err
+	case ok:
+		heap.Fix(m, 0)
+	default:
+		heap.Pop(m)
+	}
+	return n, true
+}
+
+// err returns the error reading the runs, if any.
+func (m *merger) err() error {
+	return m.e
+}
@@ -0,0 +1,99 @@
+package dec01
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+// drain returns the numbers left in the merger.
+func drain(t *testing.T, m *merger) []int {
+	t.Helper()
+	var ret []int
+	for {
+		n, ok := m.next()
+		if !ok {
+			break
+		}
+		ret = append(ret, n)
+	}
+	if err := m.err(); err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+func TestSorter(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, limit := range []int{0, 1, 3, 100} {
+		s := &sorter{limit: limit}
+		var want []int
+		for range 50 {
+			n := rnd.Intn(100) - 50
+			want = append(want, n)
+			if err := s.add(n); err != nil {
+				t.Fatal(err)
+			}
+		}
+		slices.Sort(want)
+		m, err := s.sorted()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := drain(t, m); !slices.Equal(got, want) {
+			t.Errorf("limit %d: got %v, want %v", limit, got, want)
+		}
+		if limit > 0 && limit < 50 && len(s.runs) != (50+limit-1)/limit {
+			t.Errorf("limit %d: got %d runs, want %d", limit, len(s.runs), (50+limit-1)/limit)
+		}
+		dir := s.dir
+		s.close()
+		if dir != "" {
+			if _, err := os.Stat(dir); !os.IsNotExist(err) {
+				t.Errorf("limit %d: got %v for the runs after closing, want them removed", limit, err)
+			}
+		}
+	}
+}
+
+func TestSpillFails(t *testing.T) {
+	t.Setenv("TMPDIR", filepath.Join(t.TempDir(), "missing"))
+	s := &sorter{limit: 2}
+	defer s.close()
+	if err := s.add(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.add(2); err == nil {
+		t.Error("got no error spilling to a missing directory")
+	}
+
+	b, err := os.ReadFile("base.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := solver.NewInput(string(b))
+	in.Params["memory"] = "2"
+	if _, err := part1(in); err == nil {
+		t.Error("got no error from part 1 spilling to a missing directory")
+	}
+}
+
+func TestMissingRun(t *testing.T) {
+	s := &sorter{limit: 2}
+	defer s.close()
+	for _, n := range []int{3, 1, 2} {
+		if err := s.add(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Remove(s.runs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.sorted(); err == nil {
+		t.Error("got no error reading a missing run")
+	}
+}
@@ -0,0 +1,192 @@
+package dec02
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+// direction is the way that levels may change from one to the next.
+type direction string
+
+const (
+	increasing   direction = "increasing"
+	decreasing   direction = "decreasing"
+	monotonic    direction = "monotonic" // increasing or decreasing throughout the report
+	anyDirection direction = "any"       // increases and decreases may be mixed
+)
+
+// policy decides which reports are safe.
+type policy struct {
+	MinStep    int       `json:"min_step"`
+	MaxStep    int       `json:"max_step"`
+	Direction  direction `json:"direction"`
+	AllowEqual bool      `json:"allow_equal"` // equal levels are tolerated even if the minimum step is above 0
+}
+
+// defaultPolicy is the policy of the puzzle.
+var defaultPolicy = policy{MinStep: 1, MaxStep: 3, Direction: monotonic}
+
+func (p policy) validate() error {
+	switch p.Direction {
+	case increasing, decreasing, monotonic, anyDirection:
+	default:
+		return fmt.Errorf("invalid direction %q, want one of increasing, decreasing, monotonic or any", p.Direction)
+	}
+	if p.MinStep < 0 || p.MaxStep < p.MinStep {
+		return fmt.Errorf("invalid steps %d..%d", p.MinStep, p.MaxStep)
+	}
+	return nil
+}
+
+// loadPolicy returns the policy in the JSON file named by the policy parameter, or
+// the default policy, with the min, max, direction and equal parameters overriding
+// its fields.
+func loadPolicy(in *solver.Input) (policy, error) {
+	p := defaultPolicy
+	if file, ok := in.Params["policy"]; ok {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return p, err
+		}
+		if err := json.Unmarshal(b, &p); err != nil {
+			return p, fmt.Errorf("policy %s: %v", file, err)
+		}
+	}
+	var err error
+	if p.MinStep, err = in.Int("min", p.MinStep); err != nil {
+		return p, err
+	}
+	if p.MaxStep, err = in.Int("max", p.MaxStep); err != nil {
+		return p, err
+	}
+	if d, ok := in.Params["direction"]; ok {
+		p.Direction = direction(d)
+	}
+	if p.AllowEqual, err = in.Bool("equal", p.AllowEqual); err != nil {
+		return p, err
+	}
+	return p, p.validate()
+}
+
+// stepProblem returns how a change between adjacent levels breaks the step sizes
+// of the policy, or a blank string if it doesn't.
+func (p policy) stepProblem(diff int) string {
+	if diff == 0 && p.AllowEqual {
+		return ""
+	}
+	step := diff
+	if step < 0 {
+		step = -step
+	}
+	switch {
+	case step == 0 && p.MinStep > 0:
+		return "equal levels"
+	case step < p.MinStep:
+		return fmt.Sprintf("step of %d is below %d", step, p.MinStep)
+	case step > p.MaxStep:
+		return fmt.Sprintf("step of %d is above %d", step, p.MaxStep)
+	}
+	return ""
+}
+
+// allows returns true if the levels may be adjacent in a report that changes in
+// the supplied direction, which is not monotonic.
+func (p policy) allows(from, to int, dir direction) bool {
+	diff := to - from
+	if p.stepProblem(diff) != "" {
+		return false
+	}
+	switch dir {
+	case increasing:
+		return diff >= 0
+	case decreasing:
+		return diff <= 0
+	}
+	return true
+}
+
+// violation returns the index of the first level that breaks the policy along with
+// the reason, or -1 if the report is safe.
+func (p policy) violation(levels []int) (int, string) {
+	dir := p.Direction
+	for i := 1; i < len(levels); i++ {
+		diff := levels[i] - levels[i-1]
+		if problem := p.stepProblem(diff); problem != "" {
+			return i, problem
+		}
+		if diff == 0 {
+			continue
+		}
+		if dir == monotonic {
+			dir = increasing
+			if diff < 0 {
+				dir = decreasing
+			}
+		}
+		switch {
+		case dir == increasing && diff < 0:
+			return i, "decrease in an increasing report"
+		case dir == decreasing && diff > 0:
+			return i, "increase in a decreasing report"
+		}
+	}
+	return -1, ""
+}
+
+// longest returns the indices of the longest subsequence of levels that changes in
+// the supplied direction, which is not monotonic, and keeps to the step sizes. The
+// longest subsequence ending at each level extends the longest one ending at an
+// earlier level that may precede it.
+func (p policy) longest(levels []int, dir direction) []int {
+	length := make([]int, len(levels))
+	prev := make([]int, len(levels))
+	end := 0
+	for i := range levels {
+		length[i], prev[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if length[j]+1 > length[i] && p.allows(levels[j], levels[i], dir) {
+				length[i], prev[i] = length[j]+1, j
+			}
+		}
+		if length[i] > length[end] {
+			end = i
+		}
+	}
+	var ret []int
+	for i := end; i >= 0; i = prev[i] {
+		ret = append(ret, i)
+	}
+	slices.Reverse(ret)
+	return ret
+}
+
+// dampen returns the indices of the fewest levels to remove for the report to be
+// safe, which are those outside the longest safe subsequence.
+func (p policy) dampen(levels []int) []int {
+	if len(levels) == 0 {
+		return nil
+	}
+	dirs := []direction{p.Direction}
+	if p.Direction == monotonic {
+		dirs = []direction{increasing, decreasing}
+	}
+	var kept []int
+	for _, dir := range dirs {
+		if l := p.longest(levels, dir); len(l) > len(kept) {
+			kept = l
+		}
+	}
+	var ret []int
+	for i := range levels {
+		if len(kept) > 0 && kept[0] == i {
+			kept = kept[1:]
+			continue
+		}
+		ret = append(ret, i)
+	}
+	return ret
+}
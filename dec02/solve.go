@@ -0,0 +1,104 @@
+package dec02
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 2, Part1: part1, Part2: part2, Files: []string{"policy"}})
+}
+
+// verdict is the outcome of checking a report against a policy.
+type verdict struct {
+	index   int    // the first level that breaks the policy, -1 if the whole report is safe
+	reason  string // how the level breaks the policy
+	removed []int  // the fewest levels to remove for the report to be safe when dampening
+	safe    bool
+}
+
+func (v verdict) String() string {
+	if v.index < 0 {
+		return "safe"
+	}
+	s := fmt.Sprintf("unsafe at index %d: %s", v.index, v.reason)
+	switch {
+	case len(v.removed) == 0:
+		return s
+	case v.safe:
+		return fmt.Sprintf("safe without indices %s", join(v.removed))
+	default:
+		return fmt.Sprintf("%s, safe without indices %s", s, join(v.removed))
+	}
+}
+
+func join(indices []int) string {
+	strs := make([]string, len(indices))
+	for i, n := range indices {
+		strs[i] = strconv.Itoa(n)
+	}
+	return strings.Join(strs, " ")
+}
+
+func check(p policy, levels []int) verdict {
+	index, reason := p.violation(levels)
+	return verdict{index: index, reason: reason, safe: index < 0}
+}
+
+// checkWithDampening returns a verdict that is safe if removing at most the supplied
+// number of levels makes the report safe.
+func checkWithDampening(removals int) func(p policy, levels []int) verdict {
+	return func(p policy, levels []int) verdict {
+		v := check(p, levels)
+		if v.safe {
+			return v
+		}
+		v.removed = p.dampen(levels)
+		v.safe = len(v.removed) <= removals
+		return v
+	}
+}
+
+func countSafe(in *solver.Input, check func(policy, []int) verdict) (any, error) {
+	p, err := loadPolicy(in)
+	if err != nil {
+		return nil, err
+	}
+	count := 0
+	var removals []int // the number of reports that need each number of removals
+	for _, l := range scan.Lines(in.Text) {
+		levels, err := l.Ints(" ")
+		if err != nil {
+			return nil, err
+		}
+		v := check(p, levels)
+		in.Debugf("line %d: %v", l.Num, v)
+		if v.safe {
+			count++
+		}
+		for len(removals) <= len(v.removed) {
+			removals = append(removals, 0)
+		}
+		removals[len(v.removed)]++
+	}
+	if len(removals) > 1 {
+		in.Diagnose("removals", removals)
+	}
+	return count, nil
+}
+
+func part1(in *solver.Input) (any, error) {
+	return countSafe(in, check)
+}
+
+func part2(in *solver.Input) (any, error) {
+	removals, err := in.Int("removals", 1)
+	if err != nil {
+		return nil, err
+	}
+	return countSafe(in, checkWithDampening(removals))
+}
@@ -0,0 +1,88 @@
+package dec03
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/scan"
+)
+
+// token is an instruction found in the corrupted memory.
+type token struct {
+	inst   *instruction
+	args   []int
+	text   string // the text of the instruction, e.g. "mul(2,4)"
+	offset int    // byte offset of the instruction in the input
+}
+
+// digits returns the number of decimal digits at the start of s.
+func digits(s string) int {
+	n := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+	return n
+}
+
+// match returns the operands and length of the instruction at the byte offset of
+// the line, which is its name followed by its operands in the form "(a,b)", or a
+// length of 0 if the text isn't the instruction.
+func (i *instruction) match(line scan.Line, start int) (args []int, n int, err error) {
+	s := line.Text[start:]
+	if !strings.HasPrefix(s, i.name+"(") {
+		return nil, 0, nil
+	}
+	pos := len(i.name) + 1
+	for a := 0; a < i.arity; a++ {
+		if a > 0 {
+			if pos >= len(s) || s[pos] != ',' {
+				return nil, 0, nil
+			}
+			pos++
+		}
+		d := digits(s[pos:])
+		if d == 0 {
+			return nil, 0, nil
+		}
+		args = append(args, pos)
+		pos += d
+	}
+	if pos >= len(s) || s[pos] != ')' {
+		return nil, 0, nil
+	}
+	// only convert the operands once the whole instruction has matched
+	for a, p := range args {
+		d := digits(s[p:])
+		v, err := strconv.Atoi(s[p : p+d])
+		if err != nil {
+			return nil, 0, line.Span(start+p+1, d, "number in range")
+		}
+		args[a] = v
+	}
+	return args, pos + 1, nil
+}
+
+// lex calls fn for every instruction of the set in the line, skipping any other
+// text. The offset is the byte offset of the line in the input.
+func lex(line scan.Line, offset int, set instructionSet, fn func(t token) error) error {
+	text := line.Text
+	for pos := 0; pos < len(text); {
+		n := 0
+		for _, inst := range set.starting(text[pos]) {
+			args, size, err := inst.match(line, pos)
+			if err != nil {
+				return err
+			}
+			if size == 0 {
+				continue
+			}
+			if err := fn(token{inst: inst, args: args, text: text[pos : pos+size], offset: offset + pos}); err != nil {
+				return err
+			}
+			n = size
+			break
+		}
+		pos += max(n, 1)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package dec03
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 3, Part1: part1, Part2: part2, Stream: true})
+	register(instruction{name: "mul", arity: 2, value: func(args []int) int { return args[0] * args[1] }})
+	register(instruction{name: "add", arity: 2, value: func(args []int) int { return args[0] + args[1] }})
+	register(instruction{name: "sub", arity: 2, value: func(args []int) int { return args[0] - args[1] }})
+	register(instruction{name: "do", toggle: func(bool) bool { return true }})
+	register(instruction{name: "don't", toggle: func(bool) bool { return false }})
+	register(instruction{name: "toggle", toggle: func(enabled bool) bool { return !enabled }})
+}
+
+// instruction is an instruction that may be found in the corrupted memory. It either
+// adds a value to the result or toggles whether later instructions are enabled.
+type instruction struct {
+	name   string
+	arity  int                     // number of operands
+	value  func(args []int) int    // the value added to the result when enabled, nil for toggles
+	toggle func(enabled bool) bool // returns whether later instructions are enabled
+}
+
+var instructions = map[string]*instruction{}
+
+// register adds an instruction that can be used in an instruction set.
+func register(i instruction) {
+	if (i.value == nil) == (i.toggle == nil) {
+		panic(fmt.Sprintf("instruction %s must either have a value or toggle", i.name))
+	}
+	if _, ok := instructions[i.name]; ok {
+		panic(fmt.Sprintf("instruction %s registered twice", i.name))
+	}
+	instructions[i.name] = &i
+}
+
+// instructionSet is the set of instructions that are recognised in the memory,
+// any other text is corrupted.
+type instructionSet map[byte][]*instruction // by the first letter of the name
+
+// newInstructionSet returns the set of the named instructions.
+func newInstructionSet(names ...string) (instructionSet, error) {
+	set := instructionSet{}
+	for _, name := range names {
+		i, ok := instructions[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown instruction %q", name)
+		}
+		set[name[0]] = append(set[name[0]], i)
+	}
+	return set, nil
+}
+
+// starting returns the instructions with names starting with the character.
+func (s instructionSet) starting(c byte) []*instruction {
+	return s[c]
+}
+
+// run interprets the instructions of the set in the input and returns the sum of
+// the values of the enabled instructions.
+func run(in *solver.Input, names ...string) (any, error) {
+	if s, ok := in.Params["instructions"]; ok {
+		names = strings.Split(s, ",")
+	}
+	set, err := newInstructionSet(names...)
+	if err != nil {
+		return nil, err
+	}
+	r, err := in.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = r.Close() }()
+
+	result := 0
+	enabled := true
+	err = scan.Read(r, func(line scan.Line) error {
+		return lex(line, line.Offset, set, func(t token) error {
+			if t.inst.toggle != nil {
+				enabled = t.inst.toggle(enabled)
+				in.Tracef("%8d %-20s enabled=%t", t.offset, t.text, enabled)
+				return nil
+			}
+			if !enabled {
+				in.Tracef("%8d %-20s disabled", t.offset, t.text)
+				return nil
+			}
+			v := t.inst.value(t.args)
+			result += v
+			in.Tracef("%8d %-20s %+d", t.offset, t.text, v)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func part1(in *solver.Input) (any, error) {
+	return run(in, "mul")
+}
+
+func part2(in *solver.Input) (any, error) {
+	return run(in, "mul", "do", "don't")
+}
@@ -0,0 +1,121 @@
+package dec04
+
+import (
+	"github.com/gotwarlost/aoc2024/grid"
+)
+
+// compass names the directions of grid.Offsets8.
+var compass = []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// match is an occurrence of a word in the grid.
+type match struct {
+	word  int        // index of the word
+	start grid.Point // the first letter
+	dir   int        // index of the direction in grid.Offsets8
+}
+
+// cells returns the points of the letters of the match.
+func (m match) cells(words []string) []grid.Point {
+	ret := make([]grid.Point, len(words[m.word]))
+	p := m.start
+	for i := range ret {
+		ret[i] = p
+		p = p.Add(grid.Offsets8[m.dir])
+	}
+	return ret
+}
+
+// node is a state of the automaton, the prefix of one or more words.
+type node struct {
+	next map[rune]int
+	fail int   // the state for the longest proper suffix of this prefix
+	out  []int // the words that end at this state, including those of its suffixes
+}
+
+// automaton is an Aho-Corasick automaton that finds every occurrence of a set of
+// words in a single pass over a sequence of letters.
+type automaton struct {
+	nodes []node
+	words []string
+}
+
+func newAutomaton(words []string) *automaton {
+	a := &automaton{nodes: []node{{next: map[rune]int{}}}, words: words}
+	for w, word := range words {
+		state := 0
+		for _, ch := range word {
+			next, ok := a.nodes[state].next[ch]
+			if !ok {
+				next = len(a.nodes)
+				a.nodes = append(a.nodes, node{next: map[rune]int{}})
+				a.nodes[state].next[ch] = next
+			}
+			state = next
+		}
+		a.nodes[state].out = append(a.nodes[state].out, w)
+	}
+	// the failure links of a state depend on those of shorter prefixes, so they are
+	// set breadth first
+	queue := []int{}
+	for _, next := range a.nodes[0].next {
+		queue = append(queue, next)
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for ch, next := range a.nodes[state].next {
+			fail := a.nodes[state].fail
+			for fail != 0 && !a.has(fail, ch) {
+				fail = a.nodes[fail].fail
+			}
+			if f, ok := a.nodes[fail].next[ch]; ok {
+				fail = f
+			}
+			a.nodes[next].fail = fail
+			a.nodes[next].out = append(a.nodes[next].out, a.nodes[fail].out...)
+			queue = append(queue, next)
+		}
+	}
+	return a
+}
+
+func (a *automaton) has(state int, ch rune) bool {
+	_, ok := a.nodes[state].next[ch]
+	return ok
+}
+
+// step returns the state after reading a letter.
+func (a *automaton) step(state int, ch rune) int {
+	for state != 0 && !a.has(state, ch) {
+		state = a.nodes[state].fail
+	}
+	return a.nodes[state].next[ch]
+}
+
+// search returns every occurrence of the words in the grid in all 8 directions.
+// The automaton reads each line of the grid in each direction, starting from the
+// cells at the edge that the direction leads away from. A word of one letter reads
+// the same in every direction, so it is only matched going north.
+func (a *automaton) search(g *grid.Grid[rune]) []match {
+	var ret []match
+	for dir, offset := range grid.Offsets8 {
+		for _, start := range g.Points() {
+			if g.In(start.Sub(offset)) {
+				continue
+			}
+			state := 0
+			for p := start; g.In(p); p = p.Add(offset) {
+				state = a.step(state, g.At(p))
+				for _, w := range a.nodes[state].out {
+					back := len(a.words[w]) - 1
+					if back == 0 && dir != 0 {
+						continue
+					}
+					first := grid.Point{Row: p.Row - back*offset.Row, Col: p.Col - back*offset.Col}
+					ret = append(ret, match{word: w, start: first, dir: dir})
+				}
+			}
+		}
+	}
+	return ret
+}
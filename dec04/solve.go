@@ -0,0 +1,120 @@
+package dec04
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+//go:embed templates.txt
+var defaultTemplates string
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 4, Part1: part1, Part2: part2, Files: []string{"templates"}})
+}
+
+// letters are the characters of the grid and the words.
+const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// words returns the words to search for, which default to the puzzle's XMAS.
+func words(in *solver.Input) ([]string, error) {
+	s, ok := in.Params["words"]
+	if !ok {
+		return []string{"XMAS"}, nil
+	}
+	ret := strings.Split(s, ",")
+	for _, w := range ret {
+		if w == "" || strings.Trim(w, letters) != "" {
+			return nil, fmt.Errorf("invalid word %q, want letters from A to Z", w)
+		}
+	}
+	return ret, nil
+}
+
+// highlight renders the grid with the letters at the supplied points, replacing
+// the other letters by dots.
+func highlight(g *grid.Grid[rune], points []grid.Point) string {
+	found := map[grid.Point]bool{}
+	for _, p := range points {
+		found[p] = true
+	}
+	return g.Render(func(p grid.Point, ch rune) string {
+		if !found[p] {
+			return "."
+		}
+		return string(ch)
+	})
+}
+
+func part1(in *solver.Input) (any, error) {
+	ws, err := words(in)
+	if err != nil {
+		return nil, err
+	}
+	g, err := grid.Parse(in.Text, letters, grid.Rune)
+	if err != nil {
+		return nil, err
+	}
+	matches := newAutomaton(ws).search(g)
+	counts := map[string]int{}
+	var cells []grid.Point
+	for _, m := range matches {
+		counts[ws[m.word]]++
+		cells = append(cells, m.cells(ws)...)
+		in.Tracef("%s at %d,%d going %s", ws[m.word], m.start.Row, m.start.Col, compass[m.dir])
+	}
+	in.Debugf("%s", highlight(g, cells))
+	if len(ws) > 1 {
+		in.Diagnose("found", counts)
+	}
+	return len(matches), nil
+}
+
+// loadTemplates returns the templates in the file named by the templates parameter,
+// or those of templates.txt.
+func loadTemplates(in *solver.Input) (map[string]*template, error) {
+	file, ok := in.Params["templates"]
+	if !ok {
+		return parseTemplates(defaultTemplates)
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := parseTemplates(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return ret, nil
+}
+
+func part2(in *solver.Input) (any, error) {
+	templates, err := loadTemplates(in)
+	if err != nil {
+		return nil, err
+	}
+	name := "x-mas"
+	if s, ok := in.Params["template"]; ok {
+		name = s
+	}
+	t, ok := templates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %q", name)
+	}
+	g, err := grid.Parse(in.Text, letters, grid.Rune)
+	if err != nil {
+		return nil, err
+	}
+	placements := t.place(g)
+	var cells []grid.Point
+	for _, pl := range placements {
+		cells = append(cells, t.cells(pl)...)
+		in.Tracef("%s variant %d at %d,%d", t.name, pl.variant, pl.at.Row, pl.at.Col)
+	}
+	in.Debugf("%s", highlight(g, cells))
+	return len(placements), nil
+}
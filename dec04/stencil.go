@@ -0,0 +1,154 @@
+package dec04
+
+import (
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/scan"
+)
+
+// wildcard is the template cell that matches any letter.
+const wildcard = '.'
+
+// symmetries maps the orientations a template may be placed in to the number of
+// quarter turns and whether reflections are included.
+var symmetries = map[string]struct {
+	turns   int
+	reflect bool
+}{
+	"none":    {1, false},
+	"rotate":  {4, false},
+	"reflect": {1, true},
+	"all":     {4, true},
+}
+
+// template is a 2D stencil of letters and wildcards.
+type template struct {
+	name     string
+	variants []*grid.Grid[rune] // the distinct orientations of the template
+}
+
+// placement is a variant of a template placed with its top left corner at a point.
+type placement struct {
+	variant int
+	at      grid.Point
+}
+
+// rotate returns the grid turned a quarter clockwise.
+func rotate(g *grid.Grid[rune]) *grid.Grid[rune] {
+	ret := grid.New[rune](g.Cols(), g.Rows())
+	for _, p := range ret.Points() {
+		ret.Set(p, g.At(grid.Point{Row: g.Rows() - 1 - p.Col, Col: p.Row}))
+	}
+	return ret
+}
+
+// reflect returns the grid mirrored left to right.
+func reflect(g *grid.Grid[rune]) *grid.Grid[rune] {
+	ret := grid.New[rune](g.Rows(), g.Cols())
+	for _, p := range ret.Points() {
+		ret.Set(p, g.At(grid.Point{Row: p.Row, Col: g.Cols() - 1 - p.Col}))
+	}
+	return ret
+}
+
+func newTemplate(name, sym string, g *grid.Grid[rune]) *template {
+	s := symmetries[sym]
+	t := &template{name: name}
+	seen := map[string]bool{}
+	add := func(v *grid.Grid[rune]) {
+		key := v.Render(func(_ grid.Point, ch rune) string { return string(ch) })
+		if !seen[key] {
+			seen[key] = true
+			t.variants = append(t.variants, v)
+		}
+	}
+	for i := 0; i < s.turns; i++ {
+		add(g)
+		if s.reflect {
+			add(reflect(g))
+		}
+		g = rotate(g)
+	}
+	return t
+}
+
+// parseTemplates parses a file of templates, which are described in templates.txt.
+func parseTemplates(s string) (map[string]*template, error) {
+	var lines []scan.Line
+	for _, l := range scan.Lines(s) {
+		if !strings.HasPrefix(l.Text, "#") {
+			lines = append(lines, l)
+		}
+	}
+	ret := map[string]*template{}
+	for _, block := range scan.Blocks(lines) {
+		header := block[0]
+		fields := strings.Fields(header.Text)
+		name, sym := fields[0], "none"
+		switch len(fields) {
+		case 1:
+		case 2:
+			sym = fields[1]
+			if _, ok := symmetries[sym]; !ok {
+				return nil, header.ErrorAt(strings.LastIndex(header.Text, sym)+1, "one of none, rotate, reflect or all")
+			}
+		default:
+			return nil, header.ErrorAt(strings.Index(header.Text, fields[2])+1, "end of line")
+		}
+		if _, ok := ret[name]; ok {
+			return nil, header.ErrorAt(strings.Index(header.Text, name)+1, "a unique template name")
+		}
+		if len(block) < 2 {
+			return nil, header.Error("template rows")
+		}
+		g, err := grid.ParseLines(block[1:], letters+string(wildcard), grid.Rune)
+		if err != nil {
+			return nil, err
+		}
+		if len(grid.FindAll(g, wildcard)) == g.Rows()*g.Cols() {
+			return nil, block[1].Error("a template with at least one letter")
+		}
+		ret[name] = newTemplate(name, sym, g)
+	}
+	return ret, nil
+}
+
+// matches returns true if the variant placed at the point matches the letters of the grid.
+func matches(g *grid.Grid[rune], v *grid.Grid[rune], at grid.Point) bool {
+	for _, p := range v.Points() {
+		ch := v.At(p)
+		if ch != wildcard && g.At(at.Add(p)) != ch {
+			return false
+		}
+	}
+	return true
+}
+
+// place returns every placement of the template in the grid.
+func (t *template) place(g *grid.Grid[rune]) []placement {
+	var ret []placement
+	for i, v := range t.variants {
+		for row := 0; row+v.Rows() <= g.Rows(); row++ {
+			for col := 0; col+v.Cols() <= g.Cols(); col++ {
+				at := grid.Point{Row: row, Col: col}
+				if matches(g, v, at) {
+					ret = append(ret, placement{variant: i, at: at})
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// cells returns the points of the letters of a placement.
+func (t *template) cells(pl placement) []grid.Point {
+	v := t.variants[pl.variant]
+	var ret []grid.Point
+	for _, p := range v.Points() {
+		if v.At(p) != wildcard {
+			ret = append(ret, pl.at.Add(p))
+		}
+	}
+	return ret
+}
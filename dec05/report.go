@@ -0,0 +1,116 @@
+package dec05
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// violation is a rule X|Y broken by an update that has Y before X.
+type violation struct {
+	Rule   string `json:"rule"`
+	XIndex int    `json:"x_index"` // index of X in the update
+	YIndex int    `json:"y_index"` // index of Y in the update
+}
+
+// report explains why an update is out of order and how it is fixed.
+type report struct {
+	Line       int         `json:"line"`
+	Update     []int       `json:"update"`
+	Corrected  []int       `json:"corrected"`
+	Moves      int         `json:"moves"` // the fewest pages to move to put the update in an order that obeys the rules
+	Violations []violation `json:"violations"`
+}
+
+// violations returns every rule broken by the pages.
+func (r *rules) violations(pages []int) []violation {
+	var ret []violation
+	for i := range pages {
+		for j := i + 1; j < len(pages); j++ {
+			if r.edges[[2]int{pages[j], pages[i]}] {
+				ret = append(ret, violation{Rule: fmt.Sprintf("%d|%d", pages[j], pages[i]), XIndex: j, YIndex: i})
+			}
+		}
+	}
+	return ret
+}
+
+// moves returns the fewest pages to move, by taking them out and putting them
+// back elsewhere, to put the pages in an order that obeys the rules. When the
+// rules only partially order the pages this may be fewer than needed to reach
+// the order returned by sort. The pages that stay in place keep their order, which
+// is possible as long as none of them must come before a page that precedes it,
+// directly or through other pages of the update, so the most pages that can stay
+// are the largest set of pages without such a pair.
+func (r *rules) moves(pages []int) int {
+	n := len(pages)
+	before := make([][]bool, n) // before[i][j] is true if page i must come before page j
+	for i := range before {
+		before[i] = make([]bool, n)
+		for j := range before[i] {
+			before[i][j] = r.edges[[2]int{pages[i], pages[j]}]
+		}
+	}
+	for k := range n {
+		for i := range n {
+			for j := range n {
+				before[i][j] = before[i][j] || (before[i][k] && before[k][j])
+			}
+		}
+	}
+	// grow sets of indices in increasing order from the candidates that are
+	// compatible with every index already in the set, abandoning a set that cannot
+	// beat the best one found so far
+	best := 0
+	var grow func(size int, candidates []int)
+	grow = func(size int, candidates []int) {
+		if size+len(candidates) <= best {
+			return
+		}
+		if len(candidates) == 0 {
+			best = size
+			return
+		}
+		first, rest := candidates[0], candidates[1:]
+		var compatible []int
+		for _, j := range rest {
+			if !before[j][first] {
+				compatible = append(compatible, j)
+			}
+		}
+		grow(size+1, compatible)
+		grow(size, rest)
+	}
+	all := make([]int, n)
+	for i := range all {
+		all[i] = i
+	}
+	grow(0, all)
+	return n - best
+}
+
+func join(pages []int) string {
+	strs := make([]string, len(pages))
+	for i, p := range pages {
+		strs[i] = strconv.Itoa(p)
+	}
+	return strings.Join(strs, ",")
+}
+
+func (rep report) text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "line %d: %s -> %s, %d of %d pages must move\n", rep.Line, join(rep.Update), join(rep.Corrected), rep.Moves, len(rep.Update))
+	for _, v := range rep.Violations {
+		fmt.Fprintf(&b, "  %s broken by index %d before index %d\n", v.Rule, v.YIndex, v.XIndex)
+	}
+	return b.String()
+}
+
+func (rep report) json() string {
+	b, err := json.Marshal(rep)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,103 @@
+package dec05
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func newTestRules(pairs ...[2]int) *rules {
+	r := newRules()
+	for _, p := range pairs {
+		r.add(p[0], p[1])
+	}
+	return r
+}
+
+func TestMoves(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules [][2]int
+		pages []int
+		want  int
+	}{
+		{"ordered", [][2]int{{1, 2}, {2, 3}}, []int{1, 2, 3}, 0},
+		{"reversed", [][2]int{{1, 2}, {2, 3}, {1, 3}}, []int{3, 2, 1}, 2},
+		{"one out of place", [][2]int{{97, 75}, {75, 47}, {97, 47}}, []int{75, 97, 47}, 1},
+		// sort returns 5,1,3,2,4 which is two moves away, but moving 1 to the front
+		// is enough
+		{"partial order", [][2]int{{1, 2}, {1, 3}, {1, 4}, {2, 4}}, []int{3, 2, 5, 1, 4}, 1},
+		// 3 and 1 are not related by a rule but 3 must come after 1 through 2
+		{"transitive", [][2]int{{1, 2}, {2, 3}}, []int{3, 1, 2}, 1},
+		// rules only apply to pages in the update
+		{"missing page", [][2]int{{1, 2}, {2, 3}}, []int{3, 1}, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := newTestRules(test.rules...).moves(test.pages); got != test.want {
+				t.Errorf("got %d moves, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+// fewestMoves returns the fewest moves to any valid order of the pages, by
+// trying every order.
+func fewestMoves(r *rules, pages []int) int {
+	best := len(pages)
+	var permute func(order, rest []int)
+	permute = func(order, rest []int) {
+		if len(rest) == 0 {
+			if len(r.violations(order)) == 0 {
+				best = min(best, len(pages)-common(pages, order))
+			}
+			return
+		}
+		for i := range rest {
+			next := slices.Concat(rest[:i], rest[i+1:])
+			permute(append(order, rest[i]), next)
+		}
+	}
+	permute(nil, pages)
+	return best
+}
+
+// common returns the length of the longest common subsequence of a and b.
+func common(a, b []int) int {
+	m := make([][]int, len(a)+1)
+	for i := range m {
+		m[i] = make([]int, len(b)+1)
+	}
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				m[i+1][j+1] = m[i][j] + 1
+			} else {
+				m[i+1][j+1] = max(m[i][j+1], m[i+1][j])
+			}
+		}
+	}
+	return m[len(a)][len(b)]
+}
+
+func TestMovesMatchesEveryOrder(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for range 500 {
+		n := 2 + rnd.Intn(5)
+		r := newRules()
+		for x := 1; x <= n; x++ {
+			for y := x + 1; y <= n; y++ {
+				if rnd.Intn(3) == 0 {
+					r.add(x, y)
+				}
+			}
+		}
+		pages := rnd.Perm(n)
+		for i := range pages {
+			pages[i]++
+		}
+		if got, want := r.moves(pages), fewestMoves(r, pages); got != want {
+			t.Fatalf("got %d moves for %v, want %d", got, pages, want)
+		}
+	}
+}
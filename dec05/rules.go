@@ -0,0 +1,131 @@
+package dec05
+
+import (
+	"container/heap"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// rules is the graph of the page ordering rules, with an edge from X to Y for
+// every rule X|Y.
+type rules struct {
+	after map[int][]int
+	edges map[[2]int]bool
+}
+
+func newRules() *rules {
+	return &rules{after: map[int][]int{}, edges: map[[2]int]bool{}}
+}
+
+func (r *rules) add(x, y int) {
+	if r.edges[[2]int{x, y}] {
+		return
+	}
+	r.edges[[2]int{x, y}] = true
+	r.after[x] = append(r.after[x], y)
+}
+
+// cycleError is returned for pages that cannot be ordered because the rules
+// between them form a cycle.
+type cycleError struct {
+	pages []int // the pages of the cycle, each ordered before the next and the last before the first
+}
+
+func (e *cycleError) Error() string {
+	var strs []string
+	for i, p := range e.pages {
+		strs = append(strs, fmt.Sprintf("%d|%d", p, e.pages[(i+1)%len(e.pages)]))
+	}
+	return fmt.Sprintf("the rules %s form a cycle", strings.Join(strs, ", "))
+}
+
+// indexHeap is a min-heap of indices.
+type indexHeap []int
+
+func (h indexHeap) Len() int           { return len(h) }
+func (h indexHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h indexHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *indexHeap) Push(x any)        { *h = append(*h, x.(int)) }
+
+func (h *indexHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// sort returns the pages ordered by a topological sort of the rules between them.
+// Of the pages that may come next, the one that appears first in the update is
+// taken, so pages that are already in order are returned unchanged. A *cycleError
+// is returned if the rules between the pages form a cycle.
+func (r *rules) sort(pages []int) ([]int, error) {
+	index := make(map[int]int, len(pages))
+	for i, p := range pages {
+		index[p] = i
+	}
+	preceding := make([][]int, len(pages)) // indices of the pages that must come before each page
+	for i, p := range pages {
+		for _, q := range r.after[p] {
+			if j, ok := index[q]; ok && j != i {
+				preceding[j] = append(preceding[j], i)
+			}
+		}
+	}
+	waiting := make([]int, len(pages)) // number of preceding pages not yet placed
+	ready := &indexHeap{}
+	for i := range pages {
+		waiting[i] = len(preceding[i])
+		if waiting[i] == 0 {
+			heap.Push(ready, i)
+		}
+	}
+	ret := make([]int, 0, len(pages))
+	for ready.Len() > 0 {
+		i := heap.Pop(ready).(int)
+		ret = append(ret, pages[i])
+		for _, q := range r.after[pages[i]] {
+			if j, ok := index[q]; ok && j != i {
+				if waiting[j]--; waiting[j] == 0 {
+					heap.Push(ready, j)
+				}
+			}
+		}
+	}
+	if len(ret) == len(pages) {
+		return ret, nil
+	}
+	return nil, &cycleError{pages: cycle(pages, preceding, waiting)}
+}
+
+// cycle returns a cycle among the pages that could not be placed. Each of them is
+// waiting for another such page, so following those from any of them must lead
+// back to a page already seen.
+func cycle(pages []int, preceding [][]int, waiting []int) []int {
+	i := slices.IndexFunc(waiting, func(w int) bool { return w > 0 })
+	seen := map[int]int{} // position of each index in the walk
+	var walk []int
+	for {
+		if pos, ok := seen[i]; ok {
+			walk = walk[pos:]
+			break
+		}
+		seen[i] = len(walk)
+		walk = append(walk, i)
+		for _, j := range preceding[i] {
+			if waiting[j] > 0 {
+				i = j
+				break
+			}
+		}
+	}
+	// the walk followed the rules backwards, the cycle is reported from the page
+	// that appears first in the update
+	slices.Reverse(walk)
+	first := slices.Index(walk, slices.Min(walk))
+	ret := make([]int, len(walk))
+	for n := range walk {
+		ret[n] = pages[walk[(first+n)%len(walk)]]
+	}
+	return ret
+}
@@ -0,0 +1,128 @@
+package dec05
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 5, Part1: part1, Part2: part2})
+}
+
+// update is a list of pages to print.
+type update struct {
+	line  scan.Line
+	pages []int
+}
+
+func midNumber(parts []int) int {
+	return parts[len(parts)/2]
+}
+
+func parseUpdate(l scan.Line) (update, error) {
+	u := update{line: l}
+	s := l.Scan()
+	seen := map[int]bool{}
+	for {
+		dup := s.Err("a page that is not already in the update")
+		n, err := s.Int()
+		if err != nil {
+			return u, err
+		}
+		if seen[n] {
+			return u, dup
+		}
+		seen[n] = true
+		u.pages = append(u.pages, n)
+		if s.Done() {
+			return u, nil
+		}
+		if err := s.Literal(","); err != nil {
+			return u, err
+		}
+	}
+}
+
+func parse(in *solver.Input) (*rules, []update, error) {
+	r := newRules()
+	var updates []update
+	orderProcess := true
+	for _, l := range scan.Lines(in.Text) {
+		if orderProcess && l.Text == "" {
+			orderProcess = false
+			continue
+		}
+		if orderProcess {
+			pages, err := l.Ints("|")
+			if err != nil {
+				return nil, nil, err
+			}
+			if len(pages) != 2 {
+				return nil, nil, l.Error("rule of the form X|Y")
+			}
+			r.add(pages[0], pages[1])
+		} else {
+			u, err := parseUpdate(l)
+			if err != nil {
+				return nil, nil, err
+			}
+			updates = append(updates, u)
+		}
+	}
+	return r, updates, nil
+}
+
+// sumMiddles sorts every update and sums the middle pages of those that were
+// already in order or not as requested. When fixing updates, those that are out
+// of order are explained at verbosity 1, as text or as JSON if the report
+// parameter is json.
+func sumMiddles(in *solver.Input, wantOrdered bool) (any, error) {
+	format := "text"
+	if s, ok := in.Params["report"]; ok {
+		format = s
+	}
+	if format != "text" && format != "json" {
+		return nil, fmt.Errorf("invalid report format %q, want text or json", format)
+	}
+	r, updates, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	total := 0
+	violations := 0
+	for _, u := range updates {
+		sorted, err := r.sort(u.pages)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", u.line.Num, err)
+		}
+		ordered := slices.Equal(sorted, u.pages)
+		if ordered == wantOrdered {
+			total += midNumber(sorted)
+		}
+		if ordered || wantOrdered {
+			continue
+		}
+		rep := report{Line: u.line.Num, Update: u.pages, Corrected: sorted, Moves: r.moves(u.pages), Violations: r.violations(u.pages)}
+		violations += len(rep.Violations)
+		if format == "json" {
+			in.Debugf("%s", rep.json())
+		} else {
+			in.Debugf("%s", rep.text())
+		}
+	}
+	if violations > 0 {
+		in.Diagnose("violations", violations)
+	}
+	return total, nil
+}
+
+func part1(in *solver.Input) (any, error) {
+	return sumMiddles(in, true)
+}
+
+func part2(in *solver.Input) (any, error) {
+	return sumMiddles(in, false)
+}
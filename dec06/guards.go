@@ -0,0 +1,199 @@
+package dec06
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+// turnRule returns the direction a guard faces after running into an obstruction.
+type turnRule func(d grid.Direction) grid.Direction
+
+var turnRules = map[string]turnRule{
+	"right":   grid.Direction.TurnRight,
+	"left":    grid.Direction.TurnLeft,
+	"reverse": grid.Direction.Reverse,
+}
+
+// guard is a guard as found in the lab.
+type guard struct {
+	start grid.Point
+	dir   grid.Direction
+	rule  string // the name of the turn rule
+	turn  turnRule
+}
+
+func (g guard) String() string {
+	return fmt.Sprintf("%v at %d,%d turning %s", g.dir, g.start.Row, g.start.Col, g.rule)
+}
+
+// setTurns sets the turn rules of the guards from the comma separated names in the
+// turns parameter, in the order the guards appear in the lab. A single name
+// applies to every guard and the default is to turn right.
+func (l *lab) setTurns(in *solver.Input) error {
+	names := []string{"right"}
+	if s, ok := in.Params["turns"]; ok {
+		names = strings.Split(s, ",")
+	}
+	if len(names) != 1 && len(names) != len(l.guards) {
+		return fmt.Errorf("got %d turn rules for %d guards", len(names), len(l.guards))
+	}
+	for i := range l.guards {
+		name := names[min(i, len(names)-1)]
+		rule, ok := turnRules[name]
+		if !ok {
+			return fmt.Errorf("invalid turn rule %q, want right, left or reverse", name)
+		}
+		l.guards[i].rule, l.guards[i].turn = name, rule
+	}
+	return nil
+}
+
+// walker is a guard walking through the lab.
+type walker struct {
+	guard
+	pos, prev grid.Point
+	dir       grid.Direction
+	steps     int    // number of moves and turns made before leaving the lab or repeating a state
+	seen      []bool // the positions and directions the guard has been in
+	exited    bool
+	looped    bool
+}
+
+func (w *walker) done() bool {
+	return w.exited || w.looped
+}
+
+func (w *walker) outcome() string {
+	switch {
+	case w.exited:
+		return fmt.Sprintf("exits from %d,%d after %d steps", w.pos.Row, w.pos.Col, w.steps)
+	case w.looped:
+		return fmt.Sprintf("loops after %d steps", w.steps)
+	default:
+		return "walking"
+	}
+}
+
+// collision is two or more guards in the same cell, or two guards that swap cells,
+// after a step.
+type collision struct {
+	step   int
+	at     grid.Point
+	guards []int // 1-based numbers of the guards in the order they appear in the lab
+	swap   bool
+}
+
+func (c collision) String() string {
+	strs := make([]string, len(c.guards))
+	for i, g := range c.guards {
+		strs[i] = fmt.Sprint(g)
+	}
+	names := strings.Join(strs[:len(strs)-1], ", ") + " and " + strs[len(strs)-1]
+	if c.swap {
+		return fmt.Sprintf("step %d: guards %s pass each other at %d,%d", c.step, names, c.at.Row, c.at.Col)
+	}
+	return fmt.Sprintf("step %d: guards %s meet at %d,%d", c.step, names, c.at.Row, c.at.Col)
+}
+
+// simulation is the result of walking every guard until it leaves the lab or loops.
+type simulation struct {
+	walkers    []*walker
+	covered    map[grid.Point]bool // the cells visited by any guard
+	collisions []collision
+}
+
+// simulate steps the guards together, each of them either moving forward or
+// turning at every step, until all of them have left the lab or are known to loop.
+// Guards that loop keep patrolling while other guards are walking, so that they
+// can still run into them. Guards do not block each other. The step function, if
+// supplied, is called after every step.
+func (l *lab) simulate(step func(s *simulation)) *simulation {
+	obs := l.obstructions
+	s := &simulation{covered: map[grid.Point]bool{}}
+	for _, g := range l.guards {
+		w := &walker{guard: g, pos: g.start, dir: g.dir, seen: make([]bool, obs.Rows()*obs.Cols()*4)}
+		w.seen[l.state(w.pos, w.dir)] = true
+		s.walkers = append(s.walkers, w)
+		s.covered[g.start] = true
+	}
+	for n := 1; ; n++ {
+		active := 0
+		for _, w := range s.walkers {
+			if !w.done() {
+				active++
+			}
+		}
+		if active == 0 {
+			return s
+		}
+		for _, w := range s.walkers {
+			if w.exited {
+				continue
+			}
+			w.prev = w.pos
+			if !w.looped {
+				w.steps = n
+			}
+			next := w.pos.Move(w.dir)
+			blocked, ok := obs.Get(next)
+			switch {
+			case !ok:
+				w.exited = true
+				continue
+			case blocked:
+				w.dir = w.turn(w.dir)
+			default:
+				w.pos = next
+				s.covered[next] = true
+			}
+			state := l.state(w.pos, w.dir)
+			if w.seen[state] {
+				w.looped = true
+			}
+			w.seen[state] = true
+		}
+		s.collide(n)
+		if step != nil {
+			step(s)
+		}
+	}
+}
+
+func (l *lab) state(p grid.Point, d grid.Direction) int {
+	return (p.Row*l.obstructions.Cols()+p.Col)*4 + int(d)
+}
+
+// collide records the collisions of the guards that are in the lab after a step,
+// including those that loop.
+func (s *simulation) collide(step int) {
+	var present []int
+	at := map[grid.Point][]int{}
+	var order []grid.Point
+	for i, w := range s.walkers {
+		if w.exited {
+			continue
+		}
+		present = append(present, i)
+		if at[w.pos] == nil {
+			order = append(order, w.pos)
+		}
+		at[w.pos] = append(at[w.pos], i+1)
+	}
+	for _, p := range order {
+		if len(at[p]) > 1 {
+			s.collisions = append(s.collisions, collision{step: step, at: p, guards: at[p]})
+		}
+	}
+	for x, i := range present {
+		a := s.walkers[i]
+		for _, j := range present[x+1:] {
+			b := s.walkers[j]
+			if a.pos != a.prev && a.pos == b.prev && b.pos == a.prev {
+				s.collisions = append(s.collisions, collision{step: step, at: a.prev, guards: []int{i + 1, j + 1}, swap: true})
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package dec06
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func simulateFile(t *testing.T, file string) *simulation {
+	t.Helper()
+	b, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l, err := parse(solver.NewInput(string(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l.simulate(nil)
+}
+
+func TestCollisions(t *testing.T) {
+	tests := []struct {
+		file string
+		want []collision
+	}{
+		{"guards.txt", []collision{
+			{step: 1, at: grid.Point{Row: 5, Col: 2}, guards: []int{2, 3}},
+			{step: 2, at: grid.Point{Row: 5, Col: 7}, guards: []int{4, 5}, swap: true},
+			{step: 4, at: grid.Point{Row: 5, Col: 5}, guards: []int{2, 5}},
+		}},
+		// guard 1 is found to loop at step 16 and keeps patrolling into the path
+		// of guard 2
+		{"crossing.txt", []collision{
+			{step: 23, at: grid.Point{Row: 4, Col: 5}, guards: []int{1, 2}},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			s := simulateFile(t, test.file)
+			if !slices.EqualFunc(s.collisions, test.want, func(a, b collision) bool {
+				return a.step == b.step && a.at == b.at && a.swap == b.swap && slices.Equal(a.guards, b.guards)
+			}) {
+				t.Errorf("got collisions %v, want %v", s.collisions, test.want)
+			}
+		})
+	}
+}
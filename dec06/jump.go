@@ -0,0 +1,190 @@
+package dec06
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/gotwarlost/aoc2024/grid"
+)
+
+// jumps is a dense copy of the lab that holds, for every cell and direction, the
+// last cell the guard reaches before running into an obstruction. Cells are
+// indexed in row-major order.
+type jumps struct {
+	rows, cols int
+	blocked    []bool
+	stop       [4][]int // indexed by direction, -1 if the guard leaves the lab instead
+}
+
+func newJumps(obstructions *grid.Grid[bool]) *jumps {
+	j := &jumps{rows: obstructions.Rows(), cols: obstructions.Cols()}
+	j.blocked = make([]bool, j.rows*j.cols)
+	for _, p := range obstructions.Points() {
+		j.blocked[j.index(p)] = obstructions.At(p)
+	}
+	for d := range j.stop {
+		j.stop[d] = make([]int, len(j.blocked))
+	}
+	// the stop for a cell is the cell itself if the next one is blocked and
+	// otherwise the stop of the next one, so cells are visited from the edge that
+	// the direction leads to
+	set := func(dir grid.Direction, p grid.Point) {
+		i := j.index(p)
+		next := p.Move(dir)
+		switch {
+		case !obstructions.In(next):
+			j.stop[dir][i] = -1
+		case obstructions.At(next):
+			j.stop[dir][i] = i
+		default:
+			j.stop[dir][i] = j.stop[dir][j.index(next)]
+		}
+	}
+	for row := 0; row < j.rows; row++ {
+		for col := 0; col < j.cols; col++ {
+			set(grid.Up, grid.Point{Row: row, Col: col})
+		}
+	}
+	for col := 0; col < j.cols; col++ {
+		for row := 0; row < j.rows; row++ {
+			set(grid.Left, grid.Point{Row: row, Col: col})
+		}
+	}
+	for row := j.rows - 1; row >= 0; row-- {
+		for col := 0; col < j.cols; col++ {
+			set(grid.Down, grid.Point{Row: row, Col: col})
+		}
+	}
+	for col := j.cols - 1; col >= 0; col-- {
+		for row := 0; row < j.rows; row++ {
+			set(grid.Right, grid.Point{Row: row, Col: col})
+		}
+	}
+	return j
+}
+
+func (j *jumps) index(p grid.Point) int {
+	return p.Row*j.cols + p.Col
+}
+
+// cut returns the cell before the added obstruction if it lies between the cell
+// and the stop in the direction, or the stop otherwise.
+func (j *jumps) cut(from, stop int, dir grid.Direction, obstruction int) int {
+	fr, fc := from/j.cols, from%j.cols
+	or, oc := obstruction/j.cols, obstruction%j.cols
+	sr, sc := stop/j.cols, stop%j.cols
+	switch dir {
+	case grid.Up:
+		if oc == fc && or < fr && (stop < 0 || or >= sr) {
+			return obstruction + j.cols
+		}
+	case grid.Down:
+		if oc == fc && or > fr && (stop < 0 || or <= sr) {
+			return obstruction - j.cols
+		}
+	case grid.Left:
+		if or == fr && oc < fc && (stop < 0 || oc >= sc) {
+			return obstruction + 1
+		}
+	case grid.Right:
+		if or == fr && oc > fc && (stop < 0 || oc <= sc) {
+			return obstruction - 1
+		}
+	}
+	return stop
+}
+
+// loops returns true if the guard at the cell facing the direction loops once the
+// obstruction is added. Only the cells where the guard turns are visited, and a
+// loop is found when the guard turns at the same cell in the same direction
+// twice. Turns are marked in seen with the generation, so that seen can be
+// reused by incrementing it.
+func (j *jumps) loops(from int, dir grid.Direction, turn turnRule, obstruction int, seen []int32, gen int32) bool {
+	pos := from
+	for {
+		next := j.cut(pos, j.stop[dir][pos], dir, obstruction)
+		if next < 0 {
+			return false
+		}
+		state := next*4 + int(dir)
+		if seen[state] == gen {
+			return true
+		}
+		seen[state] = gen
+		pos = next
+		dir = turn(dir)
+	}
+}
+
+// candidate is a cell on the path of the guard where an obstruction may be added,
+// along with where the guard is and the direction it faces just before first
+// reaching it. The path up to that point is unchanged by the obstruction.
+type candidate struct {
+	obstruction int
+	from        int
+	dir         grid.Direction
+}
+
+// candidates returns the cells on the path of the guard in the order they are
+// first reached, excluding the start.
+func (j *jumps) candidates(g guard) ([]candidate, error) {
+	var ret []candidate
+	reached := make([]bool, len(j.blocked))
+	turned := make([]bool, len(j.blocked)*4)
+	pos, dir := g.start, g.dir
+	reached[j.index(pos)] = true
+	for {
+		next := pos.Move(dir)
+		if next.Row < 0 || next.Row >= j.rows || next.Col < 0 || next.Col >= j.cols {
+			return ret, nil
+		}
+		n := j.index(next)
+		if j.blocked[n] {
+			state := j.index(pos)*4 + int(dir)
+			if turned[state] {
+				return nil, errors.New("the guard never leaves the lab")
+			}
+			turned[state] = true
+			dir = g.turn(dir)
+			continue
+		}
+		if !reached[n] {
+			reached[n] = true
+			ret = append(ret, candidate{obstruction: n, from: j.index(pos), dir: dir})
+		}
+		pos = next
+	}
+}
+
+// countLoops returns the number of candidates that make the guard loop, checking
+// them concurrently with the supplied number of workers.
+func (j *jumps) countLoops(candidates []candidate, turn turnRule, workers int) int {
+	loops := make([]bool, len(candidates))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seen := make([]int32, len(j.blocked)*4)
+			var gen int32
+			for i := range indices {
+				gen++
+				c := candidates[i]
+				loops[i] = j.loops(c.from, c.dir, turn, c.obstruction, seen, gen)
+			}
+		}()
+	}
+	for i := range candidates {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+	count := 0
+	for _, loop := range loops {
+		if loop {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,135 @@
+package dec06
+
+import (
+	"fmt"
+	"image/color"
+	"runtime"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/render"
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 6, Part1: part1, Part2: part2})
+}
+
+type lab struct {
+	guards       []guard // in the order they appear in the lab
+	obstructions *grid.Grid[bool]
+}
+
+func parse(in *solver.Input) (*lab, error) {
+	lines := scan.Lines(in.Text)
+	l := &lab{}
+	obstructions, err := grid.ParseLines(lines, ".#^>v<", func(p grid.Point, ch rune) bool {
+		if d, ok := grid.ParseDirection(ch); ok {
+			l.guards = append(l.guards, guard{start: p, dir: d})
+		}
+		return ch == '#'
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(l.guards) == 0 {
+		return nil, scan.EndOfInput(lines, `guard "^", ">", "v" or "<"`)
+	}
+	l.obstructions = obstructions
+	if err := l.setTurns(in); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// palette indices for frames of the guard walk.
+const (
+	colourFloor uint8 = iota
+	colourObstruction
+	colourVisited
+	colourGuard
+)
+
+var palette = color.Palette{
+	colourFloor:       color.RGBA{0x0f, 0x0f, 0x23, 0xff},
+	colourObstruction: color.RGBA{0xcc, 0xcc, 0xcc, 0xff},
+	colourVisited:     color.RGBA{0xff, 0xff, 0x66, 0xff},
+	colourGuard:       color.RGBA{0xff, 0x30, 0x30, 0xff},
+}
+
+// frames returns a step function for simulate that renders the guards, or nil
+// if frames are not wanted.
+func (l *lab) frames(in *solver.Input) func(s *simulation) {
+	if !in.Rendering() {
+		return nil
+	}
+	return func(s *simulation) {
+		guards := map[grid.Point]bool{}
+		for _, w := range s.walkers {
+			if !w.exited {
+				guards[w.pos] = true
+			}
+		}
+		in.Frame(render.Frame{
+			Rows:    l.obstructions.Rows(),
+			Cols:    l.obstructions.Cols(),
+			Palette: palette,
+			Cell: func(p grid.Point) uint8 {
+				switch {
+				case guards[p]:
+					return colourGuard
+				case l.obstructions.At(p):
+					return colourObstruction
+				case s.covered[p]:
+					return colourVisited
+				default:
+					return colourFloor
+				}
+			},
+		})
+	}
+}
+
+func part1(in *solver.Input) (any, error) {
+	l, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	s := l.simulate(l.frames(in))
+	looped := 0
+	for i, w := range s.walkers {
+		in.Debugf("guard %d (%v) %s", i+1, w.guard, w.outcome())
+		if w.looped {
+			looped++
+		}
+	}
+	for _, c := range s.collisions {
+		in.Debugf("%v", c)
+	}
+	if len(s.walkers) > 1 {
+		in.Diagnose("collisions", len(s.collisions))
+		in.Diagnose("looped", looped)
+	}
+	return len(s.covered), nil
+}
+
+func part2(in *solver.Input) (any, error) {
+	l, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	if len(l.guards) != 1 {
+		return nil, fmt.Errorf("obstructions can only be placed for a single guard, found %d", len(l.guards))
+	}
+	j := newJumps(l.obstructions)
+	g := l.guards[0]
+	candidates, err := j.candidates(g)
+	if err != nil {
+		return nil, err
+	}
+	workers, err := in.Int("workers", runtime.GOMAXPROCS(0))
+	if err != nil {
+		return nil, err
+	}
+	return j.countLoops(candidates, g.turn, workers), nil
+}
@@ -0,0 +1,164 @@
+package dec07
+
+import (
+	"math"
+	"math/big"
+)
+
+// backend solves equations using one representation of numbers.
+type backend[T any] struct {
+	arith   func(o *operator) arith[T]
+	equal   func(x, y T) bool
+	less    func(x, y T) bool
+	convert func(n *big.Int) T
+}
+
+// ints solves equations with machine integers and reports overflow instead of
+// wrapping around.
+var ints = backend[int]{
+	arith:   func(o *operator) arith[int] { return o.ints },
+	equal:   func(x, y int) bool { return x == y },
+	less:    func(x, y int) bool { return x < y },
+	convert: func(n *big.Int) int { return int(n.Int64()) },
+}
+
+// bigs solves equations with arbitrary precision integers, which never overflow.
+var bigs = backend[*big.Int]{
+	arith:   func(o *operator) arith[*big.Int] { return o.bigs },
+	equal:   func(x, y *big.Int) bool { return x.Cmp(y) == 0 },
+	less:    func(x, y *big.Int) bool { return x.Cmp(y) < 0 },
+	convert: func(n *big.Int) *big.Int { return n },
+}
+
+func addInt(x, y int) (int, outcome) {
+	s := x + y
+	if (s > x) != (y > 0) {
+		return 0, overflow
+	}
+	return s, one
+}
+
+func subInt(x, y int) (int, outcome) {
+	d := x - y
+	if (d < x) != (y > 0) {
+		return 0, overflow
+	}
+	return d, one
+}
+
+func mulInt(x, y int) (int, outcome) {
+	if x == 0 || y == 0 {
+		return 0, one
+	}
+	p := x * y
+	if p/y != x || (x == -1 && y == math.MinInt) || (y == -1 && x == math.MinInt) {
+		return 0, overflow
+	}
+	return p, one
+}
+
+func divInt(target, y int) (int, outcome) {
+	switch {
+	case y == 0 && target == 0:
+		return 0, anything
+	case y == 0 || target%y != 0:
+		return 0, none
+	case y == -1 && target == math.MinInt:
+		return 0, overflow
+	}
+	return target / y, one
+}
+
+// shiftInt returns the power of ten that a number is multiplied by to append the
+// digits of y to it.
+func shiftInt(y int) (int, outcome) {
+	p := 10
+	for p <= y {
+		if p > math.MaxInt/10 {
+			return 0, overflow
+		}
+		p *= 10
+	}
+	return p, one
+}
+
+func concatInt(x, y int) (int, outcome) {
+	if x < 0 || y < 0 {
+		return 0, none
+	}
+	p, res := shiftInt(y)
+	if res != one {
+		return 0, res
+	}
+	if x, res = mulInt(x, p); res != one {
+		return 0, res
+	}
+	return addInt(x, y)
+}
+
+func unconcatInt(target, y int) (int, outcome) {
+	if target < 0 || y < 0 {
+		return 0, none
+	}
+	p, res := shiftInt(y)
+	if res == overflow {
+		// y has as many digits as the largest int, so only zero can precede it
+		if target == y {
+			return 0, one
+		}
+		return 0, none
+	}
+	if target%p != y {
+		return 0, none
+	}
+	return target / p, one
+}
+
+func addBig(x, y *big.Int) (*big.Int, outcome) {
+	return new(big.Int).Add(x, y), one
+}
+
+func subBig(x, y *big.Int) (*big.Int, outcome) {
+	return new(big.Int).Sub(x, y), one
+}
+
+func mulBig(x, y *big.Int) (*big.Int, outcome) {
+	return new(big.Int).Mul(x, y), one
+}
+
+func divBig(target, y *big.Int) (*big.Int, outcome) {
+	if y.Sign() == 0 {
+		if target.Sign() == 0 {
+			return nil, anything
+		}
+		return nil, none
+	}
+	q, r := new(big.Int).QuoRem(target, y, new(big.Int))
+	if r.Sign() != 0 {
+		return nil, none
+	}
+	return q, one
+}
+
+func shiftBig(y *big.Int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(len(y.String()))), nil)
+}
+
+func concatBig(x, y *big.Int) (*big.Int, outcome) {
+	if x.Sign() < 0 || y.Sign() < 0 {
+		return nil, none
+	}
+	ret := new(big.Int).Mul(x, shiftBig(y))
+	return ret.Add(ret, y), one
+}
+
+func unconcatBig(target, y *big.Int) (*big.Int, outcome) {
+	if target.Sign() < 0 || y.Sign() < 0 {
+		return nil, none
+	}
+	q, r := new(big.Int).QuoRem(target, shiftBig(y), new(big.Int))
+	if r.Cmp(y) != 0 {
+		return nil, none
+	}
+	return q, one
+}
@@ -0,0 +1,98 @@
+package dec07
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+func init() {
+	register(operator{name: "add", symbol: "+", grows: true,
+		ints: arith[int]{apply: addInt, inverse: subInt},
+		bigs: arith[*big.Int]{apply: addBig, inverse: subBig},
+	})
+	register(operator{name: "sub", symbol: "-",
+		ints: arith[int]{apply: subInt, inverse: addInt},
+		bigs: arith[*big.Int]{apply: subBig, inverse: addBig},
+	})
+	register(operator{name: "mul", symbol: "*", grows: true,
+		ints: arith[int]{apply: mulInt, inverse: divInt},
+		bigs: arith[*big.Int]{apply: mulBig, inverse: divBig},
+	})
+	register(operator{name: "concat", symbol: "||", grows: true,
+		ints: arith[int]{apply: concatInt, inverse: unconcatInt},
+		bigs: arith[*big.Int]{apply: concatBig, inverse: unconcatBig},
+	})
+}
+
+// outcome describes the result of applying or inverting an operator.
+type outcome int
+
+const (
+	none     outcome = iota // there is no result
+	one                     // there is exactly one result
+	anything                // every left operand gives the target
+	overflow                // the result does not fit in the numbers of the backend
+)
+
+// arith is an operator implemented for the numbers of a backend.
+type arith[T any] struct {
+	apply   func(x, y T) (T, outcome)
+	inverse func(target, y T) (T, outcome) // returns the x for which apply(x, y) is the target
+}
+
+// operator is an operator that may be placed between the numbers of an equation.
+// Operators are evaluated left to right, without precedence.
+type operator struct {
+	name   string
+	symbol string // as printed in equations
+	grows  bool   // apply(x, y) >= x whenever x >= 0 and y >= 1
+	ints   arith[int]
+	bigs   arith[*big.Int]
+}
+
+var operators = map[string]*operator{}
+
+// register adds an operator that can be used in an operator set.
+func register(o operator) {
+	if _, ok := operators[o.name]; ok {
+		panic(fmt.Sprintf("operator %s registered twice", o.name))
+	}
+	operators[o.name] = &o
+}
+
+// operatorSet is the set of operators that may be placed between numbers, in the
+// order they are tried.
+type operatorSet []*operator
+
+// newOperatorSet returns the set of the named operators.
+func newOperatorSet(names ...string) (operatorSet, error) {
+	var set operatorSet
+	for _, name := range names {
+		o, ok := operators[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown operator %q", name)
+		}
+		set = append(set, o)
+	}
+	return set, nil
+}
+
+// grows returns true if no operator of the set can make a value smaller, so that
+// the values of an equation of positive numbers never decrease from left to right.
+func (s operatorSet) grows() bool {
+	for _, o := range s {
+		if !o.grows {
+			return false
+		}
+	}
+	return true
+}
+
+func (s operatorSet) String() string {
+	var names []string
+	for _, o := range s {
+		names = append(names, o.name)
+	}
+	return strings.Join(names, ",")
+}
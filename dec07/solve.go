@@ -0,0 +1,245 @@
+package dec07
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 7, Part1: part1, Part2: part2})
+}
+
+type expr struct {
+	line     int
+	expected *big.Int
+	parts    []*big.Int
+}
+
+func (e expr) fitsInt() bool {
+	return e.expected.IsInt64() && !slices.ContainsFunc(e.parts, func(n *big.Int) bool { return !n.IsInt64() })
+}
+
+// search looks for the operators of an equation using the numbers of a backend.
+type search[T any] struct {
+	ops        []arith[T]
+	equal      func(x, y T) bool
+	less       func(x, y T) bool
+	parts      []T
+	floor      bool  // whether targets below the first number can be discarded
+	chosen     []int // the index of the operator before each number after the first
+	overflowed bool
+}
+
+// solve returns the operators that make the equation true, if any, and whether a
+// value did not fit in the numbers of the backend along the way.
+func solve[T any](b backend[T], set operatorSet, e expr) (ops []*operator, ok, overflowed bool) {
+	s := &search[T]{equal: b.equal, less: b.less, chosen: make([]int, len(e.parts)-1)}
+	for _, o := range set {
+		s.ops = append(s.ops, b.arith(o))
+	}
+	for _, n := range e.parts {
+		s.parts = append(s.parts, b.convert(n))
+	}
+	// when values never decrease from left to right, each of them is at least the
+	// first number, which stops the search as soon as a target falls below it
+	s.floor = set.grows() && e.parts[0].Sign() >= 0 && !slices.ContainsFunc(e.parts[1:], func(n *big.Int) bool { return n.Sign() <= 0 })
+	if !s.reverse(b.convert(e.expected), len(s.parts)-1) {
+		return nil, false, s.overflowed
+	}
+	for _, i := range s.chosen {
+		ops = append(ops, set[i])
+	}
+	return ops, true, s.overflowed
+}
+
+// reverse works backwards from the target, which is the value the numbers up to
+// and including index i must produce. The operator before the number is undone
+// with its inverse, which fails for most operators and targets, so that few
+// branches are explored.
+func (s *search[T]) reverse(target T, i int) bool {
+	if i == 0 {
+		return s.equal(target, s.parts[0])
+	}
+	if s.floor && s.less(target, s.parts[0]) {
+		return false
+	}
+	for k, op := range s.ops {
+		x, res := op.inverse(target, s.parts[i])
+		switch res {
+		case overflow:
+			s.overflowed = true
+		case one:
+			s.chosen[i-1] = k
+			if s.reverse(x, i-1) {
+				return true
+			}
+		case anything:
+			// the numbers before this one may produce any value
+			s.chosen[i-1] = k
+			if s.forward(s.parts[0], 1, i) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// forward looks for operators that can be applied to the value and the numbers
+// from index i up to but excluding end.
+func (s *search[T]) forward(value T, i, end int) bool {
+	if i == end {
+		return true
+	}
+	for k, op := range s.ops {
+		v, res := op.apply(value, s.parts[i])
+		if res == overflow {
+			s.overflowed = true
+		}
+		if res != one {
+			continue
+		}
+		s.chosen[i-1] = k
+		if s.forward(v, i+1, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// solve returns the operators that make the equation true using the numbers param,
+// which is one of int, big or auto. Auto uses machine integers unless the
+// equation does not fit in them.
+func (e expr) solve(set operatorSet, numbers string) ([]*operator, bool, error) {
+	switch numbers {
+	case "big":
+		ops, ok, _ := solve(bigs, set, e)
+		return ops, ok, nil
+	case "int":
+		if !e.fitsInt() {
+			return nil, false, fmt.Errorf("line %d: numbers do not fit in an int", e.line)
+		}
+		ops, ok, overflowed := solve(ints, set, e)
+		if !ok && overflowed {
+			return nil, false, fmt.Errorf("line %d: the equation overflows an int", e.line)
+		}
+		return ops, ok, nil
+	}
+	if e.fitsInt() {
+		ops, ok, overflowed := solve(ints, set, e)
+		if ok || !overflowed {
+			return ops, ok, nil
+		}
+	}
+	ops, ok, _ := solve(bigs, set, e)
+	return ops, ok, nil
+}
+
+func (e expr) equation(ops []*operator) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v = %v", e.expected, e.parts[0])
+	for i, op := range ops {
+		fmt.Fprintf(&b, " %s %v", op.symbol, e.parts[i+1])
+	}
+	return b.String()
+}
+
+// number reads a number of any size.
+func number(sc *scan.Scanner) (*big.Int, error) {
+	neg := sc.Literal("-") == nil
+	if !neg {
+		_ = sc.Literal("+")
+	}
+	digits, err := sc.Word("number", "0123456789")
+	if err != nil {
+		return nil, err
+	}
+	n, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		n.Neg(n)
+	}
+	return n, nil
+}
+
+func parse(in *solver.Input) ([]expr, error) {
+	var expressions []expr
+	for _, line := range scan.Lines(in.Text) {
+		sc := line.Scan()
+		expected, err := number(sc)
+		if err != nil {
+			return nil, err
+		}
+		if err := sc.Literal(": "); err != nil {
+			return nil, err
+		}
+		var parts []*big.Int
+		for {
+			n, err := number(sc)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, n)
+			if sc.Done() {
+				break
+			}
+			if err := sc.Literal(" "); err != nil {
+				return nil, err
+			}
+			sc.Spaces()
+		}
+		expressions = append(expressions, expr{line: line.Num, expected: expected, parts: parts})
+	}
+	return expressions, nil
+}
+
+func sum(in *solver.Input, names ...string) (any, error) {
+	if s, ok := in.Params["operators"]; ok {
+		names = strings.Split(s, ",")
+	}
+	set, err := newOperatorSet(names...)
+	if err != nil {
+		return nil, err
+	}
+	numbers := in.Params["numbers"]
+	switch numbers {
+	case "":
+		numbers = "auto"
+	case "auto", "int", "big":
+	default:
+		return nil, fmt.Errorf("invalid numbers %q, want auto, int or big", numbers)
+	}
+	expressions, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	in.Tracef("operators %v", set)
+	sum := new(big.Int)
+	for _, e := range expressions {
+		ops, ok, err := e.solve(set, numbers)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			in.Tracef("line %d: no solution", e.line)
+			continue
+		}
+		in.Debugf("line %d: %s", e.line, e.equation(ops))
+		sum.Add(sum, e.expected)
+	}
+	if sum.IsInt64() {
+		return int(sum.Int64()), nil
+	}
+	return sum, nil
+}
+
+func part1(in *solver.Input) (any, error) {
+	return sum(in, "add", "mul")
+}
+
+func part2(in *solver.Input) (any, error) {
+	return sum(in, "add", "mul", "concat")
+}
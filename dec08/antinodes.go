@@ -0,0 +1,87 @@
+package dec08
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/grid"
+)
+
+// harmonics decides which of the points in line with two antennas are antinodes.
+type harmonics struct {
+	any       bool         // every point in line is an antinode, including the antennas
+	multiples map[int]bool // the ratios of the distances to the antennas that make an antinode
+}
+
+// parseHarmonics parses a harmonic rule, which is either exact for points where
+// one antenna is twice as far as the other, any for every point in line or a
+// comma separated list of the ratios of the distances to the antennas.
+func parseHarmonics(s string) (harmonics, error) {
+	switch s {
+	case "any":
+		return harmonics{any: true}, nil
+	case "exact":
+		s = "2"
+	}
+	h := harmonics{multiples: map[int]bool{}}
+	for _, str := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(str)
+		if err != nil || n < 1 {
+			return harmonics{}, fmt.Errorf("invalid harmonics %q, want exact, any or a list of positive multiples", s)
+		}
+		h.multiples[n] = true
+	}
+	return h, nil
+}
+
+// resonates returns true if a point at the distances from the two antennas is an
+// antinode.
+func (h harmonics) resonates(d1, d2 int) bool {
+	if h.any {
+		return true
+	}
+	near, far := min(d1, d2), max(d1, d2)
+	return near > 0 && far%near == 0 && h.multiples[far/near]
+}
+
+// pair is two antennas of the same frequency.
+type pair struct {
+	frequency string
+	a, b      grid.Point
+}
+
+func (p pair) String() string {
+	return fmt.Sprintf("%s %d,%d-%d,%d", p.frequency, p.a.Row, p.a.Col, p.b.Row, p.b.Col)
+}
+
+// antinodes returns the antinodes of every pair of antennas of the same frequency,
+// along with the pairs that produce each of them. The line through a pair is
+// walked across the whole city in the smallest step between grid points on it,
+// so the distances to the antennas are exact multiples of the step.
+func (c *city) antinodes(h harmonics) map[grid.Point][]pair {
+	ret := map[grid.Point][]pair{}
+	for _, name := range slices.Sorted(maps.Keys(c.antennas)) {
+		locs := c.antennas[name].locations
+		for i := 0; i < len(locs)-1; i++ {
+			for j := i + 1; j < len(locs); j++ {
+				pr := pair{frequency: name, a: locs[i], b: locs[j]}
+				diff := pr.b.Sub(pr.a)
+				n := gcd(diff.Row, diff.Col) // the number of steps from a to b
+				step := grid.Point{Row: diff.Row / n, Col: diff.Col / n}
+				k, p := 0, pr.a // p is k steps from a
+				for c.In(p.Sub(step)) {
+					k, p = k-1, p.Sub(step)
+				}
+				for ; c.In(p); k, p = k+1, p.Add(step) {
+					if h.resonates(abs(k), abs(k-n)) {
+						ret[p] = append(ret[p], pr)
+					}
+				}
+			}
+		}
+	}
+	return ret
+}
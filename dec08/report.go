@@ -0,0 +1,96 @@
+package dec08
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/grid"
+)
+
+// frequencyReport describes the antinodes created by the antennas of a frequency.
+type frequencyReport struct {
+	Frequency string         `json:"frequency"`
+	Antennas  int            `json:"antennas"`
+	Antinodes int            `json:"antinodes"`
+	Overlaps  map[string]int `json:"overlaps,omitempty"` // the number of antinodes shared with each other frequency
+}
+
+// frequenciesOf returns the distinct frequencies of the pairs, which are in order
+// of frequency.
+func frequenciesOf(pairs []pair) []string {
+	var ret []string
+	for _, pr := range pairs {
+		if len(ret) == 0 || ret[len(ret)-1] != pr.frequency {
+			ret = append(ret, pr.frequency)
+		}
+	}
+	return ret
+}
+
+// report returns a report for every frequency in order.
+func (c *city) report(antinodes map[grid.Point][]pair) []*frequencyReport {
+	var ret []*frequencyReport
+	byName := map[string]*frequencyReport{}
+	for _, name := range slices.Sorted(maps.Keys(c.antennas)) {
+		r := &frequencyReport{Frequency: name, Antennas: len(c.antennas[name].locations), Overlaps: map[string]int{}}
+		ret = append(ret, r)
+		byName[name] = r
+	}
+	for _, pairs := range antinodes {
+		names := frequenciesOf(pairs)
+		for _, name := range names {
+			r := byName[name]
+			r.Antinodes++
+			for _, other := range names {
+				if other != name {
+					r.Overlaps[other]++
+				}
+			}
+		}
+	}
+	return ret
+}
+
+func (r *frequencyReport) text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %d antennas, %d antinodes", r.Frequency, r.Antennas, r.Antinodes)
+	for _, other := range slices.Sorted(maps.Keys(r.Overlaps)) {
+		fmt.Fprintf(&b, ", %d shared with %s", r.Overlaps[other], other)
+	}
+	return b.String()
+}
+
+func (r *frequencyReport) json() string {
+	b, err := json.Marshal(r)
+	if err != nil {
+		panic(err)
+	}
+	return string(b)
+}
+
+// overlay is the map of the antennas and antinodes of every frequency, with
+// points as row and column pairs.
+type overlay struct {
+	Rows      int                 `json:"rows"`
+	Cols      int                 `json:"cols"`
+	Antennas  map[string][][2]int `json:"antennas"`
+	Antinodes map[string][][2]int `json:"antinodes"`
+}
+
+func (c *city) overlay(antinodes map[grid.Point][]pair) overlay {
+	o := overlay{Rows: c.Rows(), Cols: c.Cols(), Antennas: map[string][][2]int{}, Antinodes: map[string][][2]int{}}
+	for name, a := range c.antennas {
+		for _, p := range a.locations {
+			o.Antennas[name] = append(o.Antennas[name], [2]int{p.Row, p.Col})
+		}
+	}
+	for _, p := range c.Points() {
+		for _, name := range frequenciesOf(antinodes[p]) {
+			o.Antinodes[name] = append(o.Antinodes[name], [2]int{p.Row, p.Col})
+		}
+	}
+	return o
+}
@@ -0,0 +1,91 @@
+package dec08
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func exampleCity(t *testing.T) (*city, map[grid.Point][]pair) {
+	t.Helper()
+	b, err := os.ReadFile("base.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := newCity(solver.NewInput(string(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := parseHarmonics("any")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, c.antinodes(h)
+}
+
+func TestReport(t *testing.T) {
+	c, antinodes := exampleCity(t)
+	var got []string
+	for _, r := range c.report(antinodes) {
+		got = append(got, r.text())
+	}
+	want := []string{
+		"0: 4 antennas, 21 antinodes, 3 shared with A",
+		"A: 3 antennas, 16 antinodes, 3 shared with 0",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got report %q, want %q", got, want)
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	b, err := os.ReadFile("base.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, _ := solver.Get(8)
+	ret, err := p.Solve(2, solver.NewInput(string(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, ok := ret.Overlay.(overlay)
+	if !ok {
+		t.Fatalf("got overlay %T, want the antinode overlay", ret.Overlay)
+	}
+	if o.Rows != 12 || o.Cols != 12 {
+		t.Errorf("got %dx%d map, want 12x12", o.Rows, o.Cols)
+	}
+	if want := [][2]int{{5, 6}, {8, 8}, {9, 9}}; !reflect.DeepEqual(o.Antennas["A"], want) {
+		t.Errorf("got A antennas %v, want %v", o.Antennas["A"], want)
+	}
+	if len(o.Antinodes["0"]) != 21 || len(o.Antinodes["A"]) != 16 {
+		t.Errorf("got %d and %d antinodes for 0 and A, want 21 and 16", len(o.Antinodes["0"]), len(o.Antinodes["A"]))
+	}
+}
+
+func TestMapFrame(t *testing.T) {
+	c, antinodes := exampleCity(t)
+	f := c.mapFrame(antinodes)
+	tests := []struct {
+		name string
+		p    grid.Point
+		want uint8
+	}{
+		{"antenna 0", grid.Point{Row: 1, Col: 8}, colourAntenna("0")},
+		{"antenna A", grid.Point{Row: 5, Col: 6}, colourAntenna("A")},
+		{"antinode of 0", grid.Point{Row: 0, Col: 1}, colourAntenna("0") + 1},
+		{"antinode of A", grid.Point{Row: 0, Col: 0}, colourAntenna("A") + 1},
+		{"shared antinode", grid.Point{Row: 1, Col: 3}, colourShared},
+		{"empty", grid.Point{Row: 0, Col: 2}, colourEmpty},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := f.Cell(test.p); got != test.want {
+				t.Errorf("got colour %d, want %d", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package dec08
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/render"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 8, Part1: part1, Part2: part2})
+}
+
+type antenna struct {
+	name      string
+	locations []grid.Point
+}
+
+func parse(g *grid.Grid[rune]) map[string]*antenna {
+	antennas := map[string]*antenna{}
+	for _, pt := range g.Points() {
+		ch := g.At(pt)
+		if ch == '.' {
+			continue
+		}
+		name := fmt.Sprintf("%c", ch)
+		a := antennas[name]
+		if a == nil {
+			a = &antenna{name: name}
+			antennas[name] = a
+		}
+		a.locations = append(a.locations, pt)
+	}
+	return antennas
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func gcd(a, b int) int {
+	a = abs(a)
+	b = abs(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+type city struct {
+	*grid.Grid[rune]
+	antennas map[string]*antenna
+}
+
+// frequencies are the characters that name antennas.
+const frequencies = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+func newCity(in *solver.Input) (*city, error) {
+	g, err := grid.Parse(in.Text, "."+frequencies, grid.Rune)
+	if err != nil {
+		return nil, err
+	}
+	return &city{Grid: g, antennas: parse(g)}, nil
+}
+
+func (c *city) render(antinodes map[grid.Point][]pair) string {
+	return c.Render(func(pt grid.Point, ch rune) string {
+		if len(antinodes[pt]) == 0 {
+			return "."
+		}
+		if ch != '.' {
+			return string(ch)
+		}
+		return "#"
+	})
+}
+
+// palette indices for frames of the city, which are followed by the colours of
+// the antennas and the antinodes of each frequency.
+const (
+	colourEmpty uint8 = iota
+	colourShared
+	colourFrequencies
+)
+
+// palette has a bright colour for the antennas of each frequency and a darker one
+// for their antinodes. Antinodes of more than one frequency are white.
+var palette = func() color.Palette {
+	ret := color.Palette{
+		colourEmpty:  color.RGBA{0x0f, 0x0f, 0x23, 0xff},
+		colourShared: color.RGBA{0xff, 0xff, 0xff, 0xff},
+	}
+	for _, c := range render.Hues(len(frequencies)) {
+		rgba := c.(color.RGBA)
+		ret = append(ret, rgba, color.RGBA{R: rgba.R / 2, G: rgba.G / 2, B: rgba.B / 2, A: 0xff})
+	}
+	return ret
+}()
+
+func colourAntenna(frequency string) uint8 {
+	return colourFrequencies + 2*uint8(strings.Index(frequencies, frequency))
+}
+
+// mapFrame draws the antennas and antinodes, coloured by frequency.
+func (c *city) mapFrame(antinodes map[grid.Point][]pair) render.Frame {
+	return render.Frame{
+		Rows:    c.Rows(),
+		Cols:    c.Cols(),
+		Palette: palette,
+		Cell: func(p grid.Point) uint8 {
+			if ch := c.At(p); ch != '.' {
+				return colourAntenna(string(ch))
+			}
+			names := frequenciesOf(antinodes[p])
+			switch len(names) {
+			case 0:
+				return colourEmpty
+			case 1:
+				return colourAntenna(names[0]) + 1
+			default:
+				return colourShared
+			}
+		},
+	}
+}
+
+// frame renders the map if frames are wanted.
+func (c *city) frame(in *solver.Input, antinodes map[grid.Point][]pair) {
+	if in.Rendering() {
+		in.Frame(c.mapFrame(antinodes))
+	}
+}
+
+// count returns the number of antinodes for the harmonics param, which defaults
+// to the supplied rule. At verbosity 1 the antinodes of each frequency are
+// reported, as text or as JSON if the report param is json, and the map is
+// printed. The antennas and antinodes of each frequency are recorded as the map of
+// the part.
+func count(in *solver.Input, rule string) (any, error) {
+	reportFormat := "text"
+	if s, ok := in.Params["report"]; ok {
+		reportFormat = s
+	}
+	if reportFormat != "text" && reportFormat != "json" {
+		return nil, fmt.Errorf("invalid report format %q, want text or json", reportFormat)
+	}
+	c, err := newCity(in)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := in.Params["harmonics"]; ok {
+		rule = s
+	}
+	h, err := parseHarmonics(rule)
+	if err != nil {
+		return nil, err
+	}
+	antinodes := c.antinodes(h)
+	for _, p := range c.Points() {
+		if len(antinodes[p]) == 0 {
+			continue
+		}
+		var strs []string
+		for _, pr := range antinodes[p] {
+			strs = append(strs, pr.String())
+		}
+		in.Tracef("%d,%d: %s", p.Row, p.Col, strings.Join(strs, ", "))
+	}
+	for _, r := range c.report(antinodes) {
+		if reportFormat == "json" {
+			in.Debugf("%s", r.json())
+		} else {
+			in.Debugf("%s", r.text())
+		}
+	}
+	shared := 0
+	for _, pairs := range antinodes {
+		if len(frequenciesOf(pairs)) > 1 {
+			shared++
+		}
+	}
+	if shared > 0 {
+		in.Diagnose("shared", shared)
+	}
+	in.Debugf("%s", c.render(antinodes))
+	in.Overlay(c.overlay(antinodes))
+	c.frame(in, antinodes)
+	return len(antinodes), nil
+}
+
+func part1(in *solver.Input) (any, error) {
+	return count(in, "exact")
+}
+
+func part2(in *solver.Input) (any, error) {
+	return count(in, "any")
+}
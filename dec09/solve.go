@@ -1,17 +1,54 @@
-package main
+package dec09
 
 import (
-	_ "embed"
 	"fmt"
-	"log"
-	"strings"
+
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
 )
 
-//go:embed input.txt
-var input string
+func init() {
+	solver.Register(solver.Puzzle{
+		Day: 9,
+		Part1: func(in *solver.Input) (any, error) {
+			layout, err := parse(in)
+			if err != nil {
+				return nil, err
+			}
+			return part1(layout), nil
+		},
+		Part2: func(in *solver.Input) (any, error) {
+			layout, err := parse(in)
+			if err != nil {
+				return nil, err
+			}
+			return part2(layout), nil
+		},
+	})
+}
 
 const emptyVal = -1
 
+// parse reads the disk map, which is a single line of digits.
+func parse(in *solver.Input) ([]int, error) {
+	lines := scan.Lines(in.Text)
+	if len(lines) == 0 {
+		return nil, scan.EndOfInput(lines, "disk map")
+	}
+	if len(lines) > 1 {
+		return nil, lines[1].Error("end of input")
+	}
+	sc := lines[0].Scan()
+	digits, err := sc.Word("digit", "0123456789")
+	if err != nil {
+		return nil, err
+	}
+	if err := sc.End(); err != nil {
+		return nil, err
+	}
+	return makeInitialLayout(digits), nil
+}
+
 func makeInitialLayout(s string) []int {
 	var layout []int
 
@@ -97,19 +134,6 @@ type blockList struct {
 	tail *block
 }
 
-func (bl *blockList) dump() {
-	node := bl.head
-	for node != nil {
-		if node.isEmpty() {
-			fmt.Printf(" E:%d", node.length())
-		} else {
-			fmt.Printf(" %d:%d", node.fileID, node.length())
-		}
-		node = node.next
-	}
-	fmt.Println()
-}
-
 func (bl *blockList) add(b *block) {
 	if bl.head == nil {
 		bl.head = b
@@ -191,9 +215,3 @@ func part2(layout []int) int {
 	}
 	return sum
 }
-
-func main() {
-	input = strings.TrimSpace(input)
-	log.Println("PART1 SUM:", part1(makeInitialLayout(input)))
-	log.Println("PART2 SUM:", part2(makeInitialLayout(input)))
-}
@@ -0,0 +1,82 @@
+package dec10
+
+import (
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 10, Part1: part1, Part2: part2})
+}
+
+type topoMap struct {
+	heights   *grid.Grid[int]
+	heads     []grid.Point
+	end       map[grid.Point]bool
+	numTrails int
+}
+
+func (g *topoMap) next(current grid.Point) []grid.Point {
+	var ret []grid.Point
+	val := g.heights.At(current)
+	for _, np := range g.heights.Neighbours4(current) {
+		newV := g.heights.At(np)
+		if newV == val+1 {
+			ret = append(ret, np)
+			if val == 8 {
+				g.end[np] = true
+				g.numTrails++
+			}
+		}
+	}
+	return ret
+}
+
+func (g *topoMap) traverse(p grid.Point) {
+	nextPoints := g.next(p)
+	for _, child := range nextPoints {
+		g.traverse(child)
+	}
+}
+
+func (g *topoMap) calculateScore() int {
+	heads := g.heads
+	score := 0
+	for _, head := range heads {
+		g.end = map[grid.Point]bool{}
+		g.traverse(head)
+		score += len(g.end)
+	}
+	return score
+}
+
+func parse(in *solver.Input) (*topoMap, error) {
+	heights, err := grid.Parse(in.Text, "0123456789", func(_ grid.Point, ch rune) int {
+		return int(ch - '0')
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &topoMap{
+		heights: heights,
+		heads:   grid.FindAll(heights, 0),
+		end:     map[grid.Point]bool{},
+	}, nil
+}
+
+func part1(in *solver.Input) (any, error) {
+	g, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	return g.calculateScore(), nil
+}
+
+func part2(in *solver.Input) (any, error) {
+	g, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	g.calculateScore()
+	return g.numTrails, nil
+}
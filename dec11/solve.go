@@ -1,15 +1,20 @@
-package main
+package dec11
 
 import (
-	_ "embed"
 	"fmt"
-	"log"
 	"strconv"
-	"strings"
+
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
 )
 
-//go:embed input.txt
-var input string
+func init() {
+	solver.Register(solver.Puzzle{
+		Day:   11,
+		Part1: func(in *solver.Input) (any, error) { return countAfter(in, 25) },
+		Part2: func(in *solver.Input) (any, error) { return countAfter(in, 75) },
+	})
+}
 
 func toNum(s string) int {
 	n, err := strconv.Atoi(s)
@@ -54,11 +59,21 @@ func advance(stoneCounters map[int]int) {
 	}
 }
 
-func main() {
-	stoneStrings := strings.Split(strings.TrimSpace(input), " ")
-	var stones []int
-	for _, s := range stoneStrings {
-		stones = append(stones, toNum(s))
+func parse(in *solver.Input) ([]int, error) {
+	lines := scan.Lines(in.Text)
+	if len(lines) == 0 {
+		return nil, scan.EndOfInput(lines, "stones")
+	}
+	if len(lines) > 1 {
+		return nil, lines[1].Error("end of input")
+	}
+	return lines[0].Ints(" ")
+}
+
+func countAfter(in *solver.Input, blinks int) (any, error) {
+	stones, err := parse(in)
+	if err != nil {
+		return nil, err
 	}
 	stoneCounters := map[int]int{}
 
@@ -74,12 +89,8 @@ func main() {
 		return counter
 	}
 
-	blinks := 75
 	for i := 0; i < blinks; i++ {
 		advance(stoneCounters)
-		if i == 24 {
-			log.Println("COUNT 25:", countStones())
-		}
 	}
-	log.Println("COUNT 75:", countStones())
+	return countStones(), nil
 }
@@ -0,0 +1,185 @@
+package dec12
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/render"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 12, Part1: part1, Part2: part2})
+}
+
+// edge is a direction and a value.
+// for example: {Up, 0} means the top-edge of the second row
+type edge struct {
+	dir   grid.Direction
+	index int
+}
+
+// getEdge returns the edge for a point on the side facing the supplied direction.
+func getEdge(d grid.Direction, p grid.Point) edge {
+	o := p.Move(d)
+	if d.Vertical() {
+		return edge{dir: d, index: o.Row}
+	}
+	return edge{dir: d, index: o.Col}
+}
+
+type cell struct {
+	value        string
+	pt           grid.Point
+	regionNumber int
+	perimeter    int
+	edges        map[edge]bool
+}
+
+type garden struct {
+	*grid.Grid[*cell]
+}
+
+func (g *garden) assignRegion(current *cell, region int) {
+	// already assigned, noop
+	if current.regionNumber != 0 {
+		return
+	}
+	current.regionNumber = region
+	for _, d := range grid.Directions {
+		next, ok := g.Get(current.pt.Move(d))
+		if ok && next.value == current.value {
+			g.assignRegion(next, region)
+			continue
+		}
+		current.perimeter++
+		current.edges[getEdge(d, current.pt)] = true
+	}
+}
+
+type area struct {
+	val        string
+	region     int
+	count      int
+	perimeters int
+	edges      map[edge][]grid.Point
+}
+
+// calculateSides calculates the sides for an area. This is done as follows:
+// For each edge, sort all points seen for that edge in the appropriate way.
+// (i.e. by columns if the edge is top or bottom or by row when it is not)
+// add a side when there are non-contiguous points for an edge.
+func (a *area) calculateSides() int {
+	sides := 0
+	for e, pts := range a.edges {
+		sort.Slice(pts, func(i, j int) bool {
+			if e.dir.Vertical() {
+				return pts[i].Col < pts[j].Col
+			}
+			return pts[i].Row < pts[j].Row
+		})
+		prev := -3 // sentinel value that makes the first edge at any index a side
+		for _, p := range pts {
+			val := p.Row
+			if e.dir.Vertical() {
+				val = p.Col
+			}
+			// if not contiguous add a side
+			if val != prev+1 {
+				sides++
+			}
+			prev = val
+		}
+	}
+	return sides
+}
+
+// plants are the characters that name the plant in a garden plot.
+const plants = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// palette has a colour for each plant.
+var palette = render.Hues(len(plants))
+
+// frame renders the regions of the garden, coloured by plant, if frames are wanted.
+func (g *garden) frame(in *solver.Input) {
+	if !in.Rendering() {
+		return
+	}
+	in.Frame(render.Frame{
+		Rows:    g.Rows(),
+		Cols:    g.Cols(),
+		Palette: palette,
+		Cell: func(p grid.Point) uint8 {
+			return uint8(strings.Index(plants, g.At(p).value))
+		},
+	})
+}
+
+func parse(in *solver.Input) (map[int]*area, error) {
+	cells, err := grid.Parse(in.Text, plants, func(pt grid.Point, ch rune) *cell {
+		return &cell{
+			value: fmt.Sprintf("%c", ch),
+			pt:    pt,
+			edges: map[edge]bool{},
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	g := &garden{cells}
+	currentRegion := 0
+
+	for _, pt := range g.Points() {
+		c := g.At(pt)
+		if c.regionNumber != 0 {
+			continue
+		}
+		currentRegion++
+		g.assignRegion(c, currentRegion)
+	}
+	g.frame(in)
+
+	// create areas per region, keyed by region number
+	areas := map[int]*area{}
+	for _, pt := range g.Points() {
+		c := g.At(pt)
+		a := areas[c.regionNumber]
+		if a == nil {
+			a = &area{val: c.value, region: c.regionNumber, edges: map[edge][]grid.Point{}}
+			areas[c.regionNumber] = a
+		}
+		a.count += 1
+		a.perimeters += c.perimeter
+		// accumulate points by edges for calculating sides
+		for k := range c.edges {
+			a.edges[k] = append(a.edges[k], c.pt)
+		}
+	}
+	return areas, nil
+}
+
+func part1(in *solver.Input) (any, error) {
+	areas, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	out := 0
+	for _, a := range areas {
+		out += a.perimeters * a.count
+	}
+	return out, nil
+}
+
+func part2(in *solver.Input) (any, error) {
+	areas, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	out := 0
+	for _, a := range areas {
+		out += a.calculateSides() * a.count
+	}
+	return out, nil
+}
@@ -1,23 +1,20 @@
-package main
+package dec13
 
 import (
-	_ "embed"
-	"log"
 	"math"
-	"regexp"
-	"strconv"
-	"strings"
-)
-
-//go:embed input.txt
-var input string
 
-var (
-	reA     = regexp.MustCompile(`^Button A: X\+(\d+), Y\+(\d+)`)
-	reB     = regexp.MustCompile(`^Button B: X\+(\d+), Y\+(\d+)`)
-	rePrize = regexp.MustCompile(`^Prize: X=(\d+), Y=(\d+)`)
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
 )
 
+func init() {
+	solver.Register(solver.Puzzle{
+		Day:   13,
+		Part1: func(in *solver.Input) (any, error) { return totalCost(in, 0, true) },
+		Part2: func(in *solver.Input) (any, error) { return totalCost(in, 10000000000000, false) },
+	})
+}
+
 type offset struct {
 	x, y int
 }
@@ -97,34 +94,59 @@ func (p *problem) solve(prizeOffset int64, constrain100 bool) *solution {
 	return nil
 }
 
-func main() {
-	lines := strings.Split(strings.TrimSpace(input)+"\n", "\n")
-	if len(lines)%4 != 0 {
-		log.Fatalln("not a 4-multiple")
+// parseOffset parses a line such as "Button A: X+94, Y+34" where the prefix is
+// "Button A: " and the operator is "+".
+func parseOffset(l scan.Line, prefix, op string) (offset, error) {
+	sc := l.Scan()
+	if err := sc.Literal(prefix + "X" + op); err != nil {
+		return offset{}, err
 	}
-
-	extractOffset := func(re *regexp.Regexp, s string) offset {
-		matches := re.FindStringSubmatch(s)
-		x, _ := strconv.Atoi(matches[1])
-		y, _ := strconv.Atoi(matches[2])
-		return offset{x, y}
+	x, err := sc.Int()
+	if err != nil {
+		return offset{}, err
+	}
+	if err := sc.Literal(", Y" + op); err != nil {
+		return offset{}, err
+	}
+	y, err := sc.Int()
+	if err != nil {
+		return offset{}, err
 	}
+	return offset{x, y}, sc.End()
+}
 
+func parse(in *solver.Input) ([]problem, error) {
+	lines := scan.Lines(in.Text)
 	var problems []problem
-	for i := 0; i < len(lines); i += 4 {
-		a := extractOffset(reA, lines[i])
-		b := extractOffset(reB, lines[i+1])
-		p := extractOffset(rePrize, lines[i+2])
+	for _, block := range scan.Blocks(lines) {
+		if len(block) != 3 {
+			return nil, block[0].Error("block of 3 lines")
+		}
+		a, err := parseOffset(block[0], "Button A: ", "+")
+		if err != nil {
+			return nil, err
+		}
+		b, err := parseOffset(block[1], "Button B: ", "+")
+		if err != nil {
+			return nil, err
+		}
+		p, err := parseOffset(block[2], "Prize: ", "=")
+		if err != nil {
+			return nil, err
+		}
 		problems = append(problems, problem{a: a, b: b, prize: p})
 	}
+	return problems, nil
+}
 
-	var totalCost1, totalCost2 int64
+func totalCost(in *solver.Input, prizeOffset int64, constrain100 bool) (any, error) {
+	problems, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	var total int64
 	for _, p := range problems {
-		c := p.solve(0, true).cost()
-		totalCost1 += c
-		c2 := p.solve(10000000000000, false).cost()
-		totalCost2 += c2
+		total += p.solve(prizeOffset, constrain100).cost()
 	}
-	log.Println("TOTAL COST1:", totalCost1)
-	log.Println("TOTAL COST2:", totalCost2)
+	return total, nil
 }
@@ -0,0 +1,220 @@
+package dec14
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/render"
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 14, Part1: part1, Part2: part2})
+}
+
+// the examples use an 11x7 grid, set the width and height params to run those.
+const gx, gy = 101, 103
+
+type robot struct {
+	x, y   int
+	vx, vy int
+}
+
+func (r *robot) move(g *room) {
+	x1 := r.x + r.vx
+	y1 := r.y + r.vy
+	if x1 >= g.cols {
+		x1 -= g.cols
+	}
+	if x1 < 0 {
+		x1 += g.cols
+	}
+	if y1 >= g.rows {
+		y1 -= g.rows
+	}
+	if y1 < 0 {
+		y1 += g.rows
+	}
+	r.x = x1
+	r.y = y1
+}
+
+type room struct {
+	rows, cols int
+}
+
+func (g *room) inMiddle(x, y int) bool {
+	return x == g.cols/2 || y == g.rows/2
+}
+
+func (g *room) solution(in *solver.Input, robots []*robot) int {
+	var q1, q2, q3, q4 int
+	middles := 0
+	for _, r := range robots {
+		if g.inMiddle(r.x, r.y) {
+			middles++
+			continue
+		}
+		switch {
+		case r.x < g.cols/2:
+			if r.y < g.rows/2 {
+				q1++
+			} else {
+				q3++
+			}
+		default:
+			if r.y < g.rows/2 {
+				q2++
+			} else {
+				q4++
+			}
+		}
+	}
+	in.Diagnose("quadrants", []int{q1, q2, q3, q4})
+	in.Diagnose("middle", middles)
+	return q1 * q2 * q3 * q4
+}
+
+type point struct {
+	x, y int
+}
+
+func (g *room) dump(i int, robots []*robot) string {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "-------------------------- %d  --------------------------------", i)
+	m := map[point]int{}
+	for _, r := range robots {
+		m[point{r.x, r.y}]++
+	}
+	for i := 0; i < g.rows; i++ {
+		for j := 0; j < g.cols; j++ {
+			n := m[point{j, i}]
+			if n == 0 {
+				fmt.Fprintf(&b, " ")
+			} else {
+				fmt.Fprintf(&b, ".")
+			}
+		}
+		fmt.Fprintf(&b, "\n")
+	}
+	return b.String()
+}
+
+var palette = color.Palette{
+	color.RGBA{0x0f, 0x0f, 0x23, 0xff}, // empty
+	color.RGBA{0x00, 0x99, 0x00, 0xff}, // one robot
+	color.RGBA{0xff, 0xff, 0x66, 0xff}, // several robots
+}
+
+// frame renders the positions of the robots if frames are wanted.
+func (g *room) frame(in *solver.Input, robots []*robot) {
+	if !in.Rendering() {
+		return
+	}
+	m := map[point]uint8{}
+	for _, r := range robots {
+		m[point{r.x, r.y}] = min(m[point{r.x, r.y}]+1, 2)
+	}
+	in.Frame(render.Frame{
+		Rows:    g.rows,
+		Cols:    g.cols,
+		Palette: palette,
+		Cell: func(p grid.Point) uint8 {
+			return m[point{p.Col, p.Row}]
+		},
+	})
+}
+
+// parseRobot parses a line such as "p=0,4 v=3,-3".
+func parseRobot(l scan.Line) (*robot, error) {
+	sc := l.Scan()
+	var nums []int
+	for _, prefix := range []string{"p=", ",", " v=", ","} {
+		if err := sc.Literal(prefix); err != nil {
+			return nil, err
+		}
+		n, err := sc.Int()
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, n)
+	}
+	if err := sc.End(); err != nil {
+		return nil, err
+	}
+	return &robot{x: nums[0], y: nums[1], vx: nums[2], vy: nums[3]}, nil
+}
+
+func parse(in *solver.Input) (room, []*robot, error) {
+	var g room
+	var err error
+	if g.rows, err = in.Int("height", gy); err != nil {
+		return g, nil, err
+	}
+	if g.cols, err = in.Int("width", gx); err != nil {
+		return g, nil, err
+	}
+	var robots []*robot
+	for _, l := range scan.Lines(in.Text) {
+		r, err := parseRobot(l)
+		if err != nil {
+			return g, nil, err
+		}
+		robots = append(robots, r)
+	}
+	return g, robots, nil
+}
+
+func part1(in *solver.Input) (any, error) {
+	g, robots, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	g.frame(in, robots)
+	for i := 0; i < 100; i++ {
+		for _, r := range robots {
+			r.move(&g)
+		}
+		g.frame(in, robots)
+	}
+	return g.solution(in, robots), nil
+}
+
+func part2(in *solver.Input) (any, error) {
+	g, robots, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	g.frame(in, robots)
+	for i := 0; i < 10000; i++ {
+		byRow := map[int]int{}
+		byCol := map[int]int{}
+		for _, r := range robots {
+			r.move(&g)
+			byCol[r.x]++
+			byRow[r.y]++
+		}
+		g.frame(in, robots)
+		threshold := 30
+		rows := 0
+		for _, n := range byRow {
+			if n >= threshold {
+				rows++
+			}
+		}
+		cols := 0
+		for _, n := range byCol {
+			if n >= threshold {
+				cols++
+			}
+		}
+		if rows > 1 && cols > 1 {
+			in.Debugf("%s", g.dump(i, robots))
+			return i + 1, nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,298 @@
+package dec15
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/render"
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{
+		Day:   15,
+		Part1: func(in *solver.Input) (any, error) { return run(in, false) },
+		Part2: func(in *solver.Input) (any, error) { return run(in, true) },
+	})
+}
+
+type kind int
+
+const (
+	empty kind = iota
+	wall
+	box
+	robot
+	lbox
+	rbox
+)
+
+type warehouse struct {
+	part2     bool
+	positions *grid.Grid[kind]
+	robotPos  grid.Point
+}
+
+func (g *warehouse) moveSingleBox(p grid.Point, o grid.Direction) {
+	what := g.positions.At(p)
+	switch what {
+	case box, lbox:
+	default:
+		panic(fmt.Sprintf("attempt to move non-box: %v", what))
+	}
+	g.positions.Set(p, empty)
+	moveRight := false
+	if what == lbox {
+		moveRight = true
+		g.positions.Set(p.Move(grid.Right), empty)
+	}
+	g.positions.Set(p.Move(o), what)
+	if moveRight {
+		g.positions.Set(p.Move(grid.Right).Move(o), rbox)
+	}
+}
+
+func (g *warehouse) thingAt(p grid.Point) kind {
+	t := g.positions.At(p)
+	if t == empty && g.robotPos == p {
+		return robot
+	}
+	return t
+}
+
+func (g *warehouse) dump(in *solver.Input, title string) {
+	in.Debugf("%s\n%s", title, g.positions.Render(func(p grid.Point, _ kind) string {
+		what := g.thingAt(p)
+		switch what {
+		case robot:
+			return "@"
+		case empty:
+			return "."
+		case wall:
+			return "#"
+		case box:
+			return "O"
+		case lbox:
+			return "["
+		case rbox:
+			return "]"
+		default:
+			panic("booyah")
+		}
+	}))
+}
+
+// palette has the colours of each kind, which are used as palette indices.
+var palette = color.Palette{
+	empty: color.RGBA{0x0f, 0x0f, 0x23, 0xff},
+	wall:  color.RGBA{0x99, 0x99, 0x99, 0xff},
+	box:   color.RGBA{0xcc, 0x88, 0x33, 0xff},
+	robot: color.RGBA{0xff, 0x30, 0x30, 0xff},
+	lbox:  color.RGBA{0xcc, 0x88, 0x33, 0xff},
+	rbox:  color.RGBA{0xaa, 0x66, 0x22, 0xff},
+}
+
+// frame renders the warehouse if frames are wanted.
+func (g *warehouse) frame(in *solver.Input) {
+	if !in.Rendering() {
+		return
+	}
+	in.Frame(render.Frame{
+		Rows:    g.positions.Rows(),
+		Cols:    g.positions.Cols(),
+		Palette: palette,
+		Cell: func(p grid.Point) uint8 {
+			return uint8(g.thingAt(p))
+		},
+	})
+}
+
+func (g *warehouse) canMoveBox(current grid.Point, o grid.Direction) bool {
+	newP := current.Move(o)
+	what := g.thingAt(newP)
+	switch what {
+	case wall:
+		return false
+	case empty:
+		return true
+	case box:
+		return g.canMoveBox(newP, o)
+	case lbox:
+		if o.Vertical() {
+			return g.canMoveBox(newP, o) && g.canMoveBox(newP.Move(grid.Right), o)
+		}
+		return g.canMoveBox(newP, o)
+	case rbox:
+		if o.Vertical() {
+			return g.canMoveBox(newP, o) && g.canMoveBox(newP.Move(grid.Left), o)
+		}
+		return g.canMoveBox(newP, o)
+	default:
+		panic(fmt.Sprintf("unexpected value: %v", what))
+	}
+}
+
+func (g *warehouse) moveBox(current grid.Point, o grid.Direction) {
+	what := g.thingAt(current)
+	switch what {
+	case box, lbox, rbox:
+	default:
+		panic(fmt.Sprintf("internal error: %v", what))
+	}
+	newP := current.Move(o)
+	newWhat := g.thingAt(newP)
+	simpleMove := func() {
+		g.positions.Set(current, empty)
+		g.positions.Set(newP, what)
+	}
+	doSimple := func() {
+		if newWhat == empty {
+			simpleMove()
+		} else {
+			g.moveBox(newP, o)
+			simpleMove()
+		}
+	}
+	if g.part2 && o.Vertical() {
+		if what == rbox {
+			g.moveBox(current.Move(grid.Left), o)
+			return
+		}
+		altOffset := grid.Right
+		altP := newP.Move(altOffset)
+		altWhat := g.thingAt(altP)
+
+		if newWhat == lbox && altWhat == rbox {
+			g.moveBox(newP, o)
+		} else {
+			if newWhat != empty {
+				g.moveBox(newP, o)
+			}
+			if altWhat != empty {
+				g.moveBox(altP, o)
+			}
+		}
+		simpleMove()
+		current = current.Move(altOffset)
+		what = g.thingAt(current)
+		newP = altP
+		simpleMove()
+	} else {
+		doSimple()
+	}
+}
+
+func (g *warehouse) advance(m grid.Direction) {
+	nextPos := g.robotPos.Move(m)
+	thing := g.positions.At(nextPos)
+	switch thing {
+	case empty:
+		g.robotPos = nextPos
+		return
+	case wall:
+		return
+	}
+
+	canDo := g.canMoveBox(nextPos, m)
+	canDo2 := true
+	if g.part2 && m.Vertical() {
+		if g.thingAt(nextPos) == lbox {
+			canDo2 = g.canMoveBox(nextPos.Move(grid.Right), m)
+		} else {
+			canDo2 = g.canMoveBox(nextPos.Move(grid.Left), m)
+		}
+	}
+	if canDo && canDo2 {
+		g.moveBox(nextPos, m)
+		g.robotPos = nextPos
+	}
+}
+
+func (g *warehouse) solution() int {
+	solution := 0
+	for _, p := range g.positions.Points() {
+		what := g.thingAt(p)
+		if what == box || what == lbox {
+			solution += 100*p.Row + p.Col
+		}
+	}
+	return solution
+}
+
+func parse(in *solver.Input, part2 bool) (g *warehouse, moves []grid.Direction, err error) {
+	lines := scan.Lines(in.Text)
+	blocks := scan.Blocks(lines)
+	if len(blocks) < 2 {
+		return nil, nil, scan.EndOfInput(lines, "moves after a blank line")
+	}
+	if len(blocks) > 2 {
+		return nil, nil, blocks[2][0].Error("end of input")
+	}
+	var robots []grid.Point
+	chars, err := grid.ParseLines(blocks[0], "#.O@", func(p grid.Point, ch rune) rune {
+		if ch == '@' {
+			robots = append(robots, p)
+		}
+		return ch
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := grid.One(blocks[0], robots, `robot "@"`); err != nil {
+		return nil, nil, err
+	}
+
+	g = &warehouse{part2: part2}
+	scale := 1
+	if part2 {
+		scale = 2
+	}
+	g.positions = grid.New[kind](chars.Rows(), chars.Cols()*scale)
+	for _, pt := range chars.Points() {
+		p := grid.Point{Row: pt.Row, Col: scale * pt.Col}
+		switch chars.At(pt) {
+		case '#':
+			g.positions.Set(p, wall)
+			if part2 {
+				g.positions.Set(p.Move(grid.Right), wall)
+			}
+		case 'O':
+			if part2 {
+				g.positions.Set(p, lbox)
+				g.positions.Set(p.Move(grid.Right), rbox)
+			} else {
+				g.positions.Set(p, box)
+			}
+		case '@':
+			g.robotPos = p
+		}
+	}
+	for _, l := range blocks[1] {
+		for j, ch := range l.Text {
+			d, ok := grid.ParseDirection(ch)
+			if !ok {
+				return nil, nil, l.CharAt(j+1, `one of "^>v<"`)
+			}
+			moves = append(moves, d)
+		}
+	}
+	return g, moves, nil
+}
+
+func run(in *solver.Input, part2 bool) (any, error) {
+	g, moves, err := parse(in, part2)
+	if err != nil {
+		return nil, err
+	}
+	g.dump(in, "initial state")
+	g.frame(in)
+
+	for _, m := range moves {
+		g.advance(m)
+		g.frame(in)
+	}
+	g.dump(in, "end state")
+	return g.solution(), nil
+}
@@ -0,0 +1,194 @@
+package dec16
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/render"
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/search"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 16, Part1: part1, Part2: part2})
+}
+
+type dirCost struct {
+	dir  grid.Direction
+	cost int
+}
+
+// candidates returns the directions that can be taken next along with their
+// costs, turning is expensive.
+func candidates(d grid.Direction) []dirCost {
+	return []dirCost{{d, 1}, {d.TurnRight(), 1001}, {d.TurnLeft(), 1001}}
+}
+
+// arrows are used to dump the route taken, indexed by direction.
+var arrows = []string{"\u25b2", "\u25b6", "\u25bc", "\u25c0"}
+
+type maze struct {
+	walls    *grid.Grid[bool]
+	startPos grid.Point
+	endPos   grid.Point
+}
+
+// palette indices for frames of the maze.
+const (
+	colourFloor uint8 = iota
+	colourWall
+	colourPath
+	colourStart
+	colourEnd
+)
+
+var palette = color.Palette{
+	colourFloor: color.RGBA{0x0f, 0x0f, 0x23, 0xff},
+	colourWall:  color.RGBA{0x99, 0x99, 0x99, 0xff},
+	colourPath:  color.RGBA{0xff, 0xff, 0x66, 0xff},
+	colourStart: color.RGBA{0x00, 0xcc, 0x00, 0xff},
+	colourEnd:   color.RGBA{0xff, 0x30, 0x30, 0xff},
+}
+
+// frame renders the maze with the supplied path if frames are wanted.
+func (m *maze) frame(in *solver.Input, path map[grid.Point]bool) {
+	if !in.Rendering() {
+		return
+	}
+	in.Frame(render.Frame{
+		Rows:    m.walls.Rows(),
+		Cols:    m.walls.Cols(),
+		Palette: palette,
+		Cell: func(p grid.Point) uint8 {
+			switch {
+			case p == m.startPos:
+				return colourStart
+			case p == m.endPos:
+				return colourEnd
+			case m.walls.At(p):
+				return colourWall
+			case path[p]:
+				return colourPath
+			default:
+				return colourFloor
+			}
+		},
+	})
+}
+
+func (m *maze) dump(in *solver.Input, visited map[grid.Point]grid.Direction) {
+	in.Debugf("%s", m.walls.Render(func(p grid.Point, wall bool) string {
+		d, ok := visited[p]
+		switch {
+		case p == m.startPos:
+			return "S"
+		case p == m.endPos:
+			return "E"
+		case ok:
+			return arrows[d]
+		case wall:
+			return "|"
+		default:
+			return " "
+		}
+	}))
+}
+
+func (m *maze) next(pd pointDir) []search.Edge[pointDir] {
+	var ret []search.Edge[pointDir]
+	for _, c := range candidates(pd.dir) {
+		nextPos := pd.pt.Move(c.dir)
+		if wall, ok := m.walls.Get(nextPos); !ok || wall {
+			continue
+		}
+		ret = append(ret, search.Edge[pointDir]{To: pointDir{nextPos, c.dir}, Cost: c.cost})
+	}
+	return ret
+}
+
+func (m *maze) solve(in *solver.Input) (*search.Result[pointDir], error) {
+	res := search.Dijkstra(search.Problem[pointDir]{
+		Start: []pointDir{{m.startPos, grid.Right}},
+		Next:  m.next,
+		Goal:  func(pd pointDir) bool { return pd.pt == m.endPos },
+		Heuristic: func(pd pointDir) int {
+			return pd.pt.Distance(m.endPos)
+		},
+	})
+	if !res.Found {
+		return nil, errors.New("no path from the start to the end")
+	}
+	route := map[grid.Point]grid.Direction{}
+	for _, pd := range res.Path() {
+		route[pd.pt] = pd.dir
+	}
+	m.dump(in, route)
+	if in.Rendering() {
+		best := map[grid.Point]bool{}
+		for pd := range res.OnOptimalPaths() {
+			best[pd.pt] = true
+		}
+		m.frame(in, best)
+	}
+	return res, nil
+}
+
+func newMaze(s string) (*maze, error) {
+	lines := scan.Lines(s)
+	var starts, ends []grid.Point
+	walls, err := grid.ParseLines(lines, "#.SE", func(pt grid.Point, ch rune) bool {
+		switch ch {
+		case 'S':
+			starts = append(starts, pt)
+		case 'E':
+			ends = append(ends, pt)
+		}
+		return ch == '#'
+	})
+	if err != nil {
+		return nil, err
+	}
+	ret := &maze{walls: walls}
+	if ret.startPos, err = grid.One(lines, starts, `start "S"`); err != nil {
+		return nil, err
+	}
+	if ret.endPos, err = grid.One(lines, ends, `end "E"`); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+type pointDir struct {
+	pt  grid.Point
+	dir grid.Direction
+}
+
+func solve(in *solver.Input) (*search.Result[pointDir], error) {
+	m, err := newMaze(in.Text)
+	if err != nil {
+		return nil, err
+	}
+	return m.solve(in)
+}
+
+func part1(in *solver.Input) (any, error) {
+	res, err := solve(in)
+	if err != nil {
+		return nil, err
+	}
+	return res.Cost, nil
+}
+
+func part2(in *solver.Input) (any, error) {
+	res, err := solve(in)
+	if err != nil {
+		return nil, err
+	}
+	visited := map[grid.Point]bool{}
+	for pd := range res.OnOptimalPaths() {
+		visited[pd.pt] = true
+	}
+	return len(visited), nil
+}
@@ -1,17 +1,35 @@
-package main
+package dec17
 
 import (
-	_ "embed"
+	"errors"
 	"fmt"
-	"log"
 	"math"
 	"sort"
-	"strconv"
 	"strings"
+
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
 )
 
-//go:embed input.txt
-var input string
+func init() {
+	solver.Register(solver.Puzzle{
+		Day: 17,
+		Part1: func(in *solver.Input) (any, error) {
+			p, err := parse(in.Text)
+			if err != nil {
+				return nil, err
+			}
+			return p.part1(), nil
+		},
+		Part2: func(in *solver.Input) (any, error) {
+			p, err := parse(in.Text)
+			if err != nil {
+				return nil, err
+			}
+			return p.part2(in)
+		},
+	})
+}
 
 type register int
 
@@ -126,44 +144,77 @@ type puzzle struct {
 	possibleAs   []int
 }
 
-func toNum(s string) int {
-	n, err := strconv.Atoi(s)
+func parseRegister(l scan.Line, name string) (int, error) {
+	sc := l.Scan()
+	if err := sc.Literal("Register " + name + ": "); err != nil {
+		return 0, err
+	}
+	n, err := sc.Int()
 	if err != nil {
-		panic(err)
+		return 0, err
 	}
-	return n
+	return n, sc.End()
 }
 
-func parse(s string) *puzzle {
-	ret := &puzzle{
-		registers: []int{0, 0, 0},
-	}
-	trimNum := func(s string, prefix string) int {
-		s = strings.TrimPrefix(s, prefix)
-		return toNum(s)
-	}
-	ra, rb, rc, prog := "Register A: ", "Register B: ", "Register C: ", "Program: "
-	for _, l := range strings.Split(strings.TrimSpace(s), "\n") {
-		switch {
-		case strings.HasPrefix(l, ra):
-			ret.registers[regA] = trimNum(l, ra)
-		case strings.HasPrefix(l, rb):
-			ret.registers[regB] = trimNum(l, rb)
-		case strings.HasPrefix(l, rc):
-			ret.registers[regC] = trimNum(l, rc)
-		case strings.HasPrefix(l, prog):
-			l = strings.TrimPrefix(l, prog)
-			ret.instStr = l
-			parts := strings.Split(l, ",")
-			if len(parts)%2 != 0 {
-				panic("odd parts")
-			}
-			for i := 0; i < len(parts); i++ {
-				ret.instructions = append(ret.instructions, toNum(parts[i]))
-			}
+func parseProgram(l scan.Line) ([]int, error) {
+	sc := l.Scan()
+	if err := sc.Literal("Program: "); err != nil {
+		return nil, err
+	}
+	var ret []int
+	for {
+		bad := sc.Err("3-bit number")
+		n, err := sc.Int()
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 || n > 7 {
+			return nil, bad
+		}
+		ret = append(ret, n)
+		if sc.Done() {
+			break
 		}
+		if err := sc.Literal(","); err != nil {
+			return nil, err
+		}
+	}
+	if len(ret)%2 != 0 {
+		return nil, sc.Err("operand")
 	}
-	return ret
+	return ret, nil
+}
+
+func parse(s string) (*puzzle, error) {
+	lines := scan.Lines(s)
+	blocks := scan.Blocks(lines)
+	if len(blocks) < 2 {
+		return nil, scan.EndOfInput(lines, "program after a blank line")
+	}
+	if len(blocks) > 2 {
+		return nil, blocks[2][0].Error("end of input")
+	}
+	if len(blocks[0]) != 3 {
+		return nil, blocks[0][0].Error("3 registers")
+	}
+	if len(blocks[1]) != 1 {
+		return nil, blocks[1][1].Error("end of input")
+	}
+	ret := &puzzle{}
+	for i, name := range []string{"A", "B", "C"} {
+		n, err := parseRegister(blocks[0][i], name)
+		if err != nil {
+			return nil, err
+		}
+		ret.registers = append(ret.registers, n)
+	}
+	instructions, err := parseProgram(blocks[1][0])
+	if err != nil {
+		return nil, err
+	}
+	ret.instructions = instructions
+	ret.instStr = strings.TrimPrefix(blocks[1][0].Text, "Program: ")
+	return ret, nil
 }
 
 func (p *puzzle) execute(part2 bool) []int {
@@ -228,16 +279,12 @@ func (p *puzzle) part2Step(currentA int, index int) {
 	}
 }
 
-func (p *puzzle) part2() int {
+func (p *puzzle) part2(in *solver.Input) (int, error) {
 	p.part2Step(0, len(p.instructions)-1)
 	sort.Ints(p.possibleAs)
-	log.Println("possible As:", p.possibleAs)
-	return p.possibleAs[0]
-}
-
-func main() {
-	puz := parse(input)
-	log.Println("OUTPUT:", puz.part1())
-	puz = parse(input)
-	log.Println("MIN A:", puz.part2())
+	in.Diagnose("possible_a", p.possibleAs)
+	if len(p.possibleAs) == 0 {
+		return 0, errors.New("no value of register A makes the program output itself")
+	}
+	return p.possibleAs[0], nil
 }
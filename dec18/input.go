@@ -0,0 +1,16 @@
+//go:build !noembed
+
+package dec18
+
+import (
+	_ "embed"
+
+	"github.com/gotwarlost/aoc2024/inputs"
+)
+
+//go:embed input.txt
+var input string
+
+func init() {
+	inputs.Embed(18, input)
+}
@@ -0,0 +1,167 @@
+package dec18
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/render"
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/search"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 18, Part1: part1, Part2: part2})
+}
+
+// the example uses a grid size of 7 and 12 bytes, set the size and bytes
+// params to run it.
+const (
+	gridSize = 71
+	numBytes = 1024
+)
+
+type memory struct {
+	*grid.Grid[bool]
+}
+
+func (g *memory) addWall(p grid.Point) {
+	g.Set(p, true)
+}
+
+// palette indices for frames of falling bytes.
+const (
+	colourFree uint8 = iota
+	colourCorrupted
+	colourPath
+	colourFalling
+)
+
+var palette = color.Palette{
+	colourFree:      color.RGBA{0x0f, 0x0f, 0x23, 0xff},
+	colourCorrupted: color.RGBA{0x99, 0x99, 0x99, 0xff},
+	colourPath:      color.RGBA{0x00, 0xcc, 0x00, 0xff},
+	colourFalling:   color.RGBA{0xff, 0x30, 0x30, 0xff},
+}
+
+// frame renders the memory space with the path to the exit and the byte that
+// fell last if frames are wanted.
+func (g *memory) frame(in *solver.Input, path map[grid.Point]bool, last grid.Point) {
+	if !in.Rendering() {
+		return
+	}
+	in.Frame(render.Frame{
+		Rows:    g.Rows(),
+		Cols:    g.Cols(),
+		Palette: palette,
+		Cell: func(p grid.Point) uint8 {
+			switch {
+			case p == last:
+				return colourFalling
+			case g.At(p):
+				return colourCorrupted
+			case path[p]:
+				return colourPath
+			default:
+				return colourFree
+			}
+		},
+	})
+}
+
+func (g *memory) possibleNextPlaces(p grid.Point) []grid.Point {
+	var ret []grid.Point
+	for _, c := range g.Neighbours4(p) {
+		if !g.At(c) {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+func parse(in *solver.Input) (ret *memory, rest []grid.Point, err error) {
+	size, err := in.Int("size", gridSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	n, err := in.Int("bytes", numBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	ret = &memory{grid.New[bool](size, size)}
+	for i, line := range scan.Lines(in.Text) {
+		coords, err := line.Ints(",")
+		if err != nil {
+			return nil, nil, err
+		}
+		p := grid.Point{}
+		if len(coords) == 2 {
+			p = grid.Point{Row: coords[1], Col: coords[0]}
+		}
+		if len(coords) != 2 || !ret.In(p) {
+			return nil, nil, line.Error(fmt.Sprintf("X,Y within the %dx%d grid", size, size))
+		}
+		if i >= n {
+			rest = append(rest, p)
+			continue
+		}
+		ret.addWall(p)
+		ret.frame(in, nil, p)
+	}
+	return ret, rest, nil
+}
+
+func (g *memory) solve() (int, map[grid.Point]bool, bool) {
+	endPos := grid.Point{Row: g.Rows() - 1, Col: g.Cols() - 1}
+	res := search.BFS(grid.Point{}, g.possibleNextPlaces, func(p grid.Point) bool {
+		return p == endPos
+	})
+	if !res.Found {
+		return 0, nil, false
+	}
+	path := map[grid.Point]bool{}
+	for _, p := range res.Path() {
+		path[p] = true
+	}
+	return res.Cost, path, true
+}
+
+var errNoPath = errors.New("no path to the exit")
+
+func part1(in *solver.Input) (any, error) {
+	g, _, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	s, path, found := g.solve()
+	if !found {
+		return nil, errNoPath
+	}
+	g.frame(in, path, grid.Point{Row: -1, Col: -1})
+	return s, nil
+}
+
+func part2(in *solver.Input) (any, error) {
+	g, rest, err := parse(in)
+	if err != nil {
+		return nil, err
+	}
+	_, path, found := g.solve()
+	if !found {
+		return nil, errNoPath
+	}
+	for _, p := range rest {
+		g.addWall(p)
+		g.frame(in, path, p)
+		if !path[p] {
+			continue
+		}
+		_, path, found = g.solve()
+		if !found {
+			return fmt.Sprintf("%d,%d", p.Col, p.Row), nil
+		}
+	}
+	return nil, errors.New("no byte blocks the path to the exit")
+}
@@ -0,0 +1,126 @@
+package dec19
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{Day: 19, Part1: part1, Part2: part2})
+}
+
+type puzzle struct {
+	stripes        []string
+	patterns       []string
+	substringCount map[string]int
+}
+
+// colours are the colours of stripes on a towel.
+const colours = "wubrg"
+
+func parse(s string) (*puzzle, error) {
+	lines := scan.Lines(s)
+	blocks := scan.Blocks(lines)
+	if len(blocks) < 2 {
+		return nil, scan.EndOfInput(lines, "designs after a blank line")
+	}
+	if len(blocks) > 2 {
+		return nil, blocks[2][0].Error("end of input")
+	}
+	if len(blocks[0]) != 1 {
+		return nil, blocks[0][1].Error("blank line")
+	}
+	ret := &puzzle{substringCount: map[string]int{}}
+	sc := blocks[0][0].Scan()
+	for {
+		stripe, err := sc.Word("towel pattern", colours)
+		if err != nil {
+			return nil, err
+		}
+		ret.stripes = append(ret.stripes, stripe)
+		if sc.Done() {
+			break
+		}
+		if err := sc.Literal(", "); err != nil {
+			return nil, err
+		}
+	}
+	for _, line := range blocks[1] {
+		sc := line.Scan()
+		design, err := sc.Word("design", colours)
+		if err != nil {
+			return nil, err
+		}
+		if err := sc.End(); err != nil {
+			return nil, err
+		}
+		ret.patterns = append(ret.patterns, design)
+	}
+	return ret, nil
+}
+
+func (p *puzzle) candidateStripes(input string) []string {
+	var ret []string
+	for _, s := range p.stripes {
+		if strings.HasPrefix(input, s) {
+			ret = append(ret, s)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return len(ret[i]) > len(ret[j])
+	})
+	return ret
+}
+
+func (p *puzzle) countPossibilities(input string) (ret int) {
+	if count, ok := p.substringCount[input]; ok {
+		return count
+	}
+	defer func() {
+		p.substringCount[input] = ret
+	}()
+	ret = 0
+	candidates := p.candidateStripes(input)
+	for _, c := range candidates {
+		next := input[len(c):]
+		if len(next) == 0 {
+			ret++
+			continue
+		}
+		children := p.countPossibilities(next)
+		ret += children
+	}
+	return ret
+}
+
+func (p *puzzle) solve() (totalPossibe int, numPossibilities int) {
+	for _, pat := range p.patterns {
+		count := p.countPossibilities(pat)
+		if count > 0 {
+			totalPossibe++
+		}
+		numPossibilities += count
+	}
+	return
+}
+
+func part1(in *solver.Input) (any, error) {
+	p, err := parse(in.Text)
+	if err != nil {
+		return nil, err
+	}
+	t, _ := p.solve()
+	return t, nil
+}
+
+func part2(in *solver.Input) (any, error) {
+	p, err := parse(in.Text)
+	if err != nil {
+		return nil, err
+	}
+	_, a := p.solve()
+	return a, nil
+}
@@ -0,0 +1,211 @@
+package dec20
+
+import (
+	"errors"
+	"image/color"
+
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/render"
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/search"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+func init() {
+	solver.Register(solver.Puzzle{
+		Day:   20,
+		Part1: func(in *solver.Input) (any, error) { return cheatsSaving(in, 2) },
+		Part2: func(in *solver.Input) (any, error) { return cheatsSaving(in, 20) },
+	})
+}
+
+// minSaving is the minimum number of picoseconds a cheat must save to be counted.
+// The examples count much smaller savings, set the min param to run those.
+const minSaving = 100
+
+type maze struct {
+	walls    *grid.Grid[bool]
+	startPos grid.Point
+	endPos   grid.Point
+}
+
+func (m *maze) possibleNextPlaces(p grid.Point) []grid.Point {
+	var ret []grid.Point
+	for _, c := range m.walls.Neighbours4(p) {
+		if !m.walls.At(c) {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+func (m *maze) solve() (solution, error) {
+	res := search.BFS(m.startPos, m.possibleNextPlaces, func(p grid.Point) bool {
+		return p == m.endPos
+	})
+	if !res.Found {
+		return nil, errors.New("no path from the start to the end")
+	}
+	return res.Path(), nil
+}
+
+// palette indices for frames of the maze.
+const (
+	colourFloor uint8 = iota
+	colourWall
+	colourPath
+	colourStart
+	colourEnd
+)
+
+var palette = color.Palette{
+	colourFloor: color.RGBA{0x0f, 0x0f, 0x23, 0xff},
+	colourWall:  color.RGBA{0x99, 0x99, 0x99, 0xff},
+	colourPath:  color.RGBA{0xff, 0xff, 0x66, 0xff},
+	colourStart: color.RGBA{0x00, 0xcc, 0x00, 0xff},
+	colourEnd:   color.RGBA{0xff, 0x30, 0x30, 0xff},
+}
+
+// frame renders the maze with the supplied path if frames are wanted.
+func (m *maze) frame(in *solver.Input, path map[grid.Point]bool) {
+	if !in.Rendering() {
+		return
+	}
+	in.Frame(render.Frame{
+		Rows:    m.walls.Rows(),
+		Cols:    m.walls.Cols(),
+		Palette: palette,
+		Cell: func(p grid.Point) uint8 {
+			switch {
+			case p == m.startPos:
+				return colourStart
+			case p == m.endPos:
+				return colourEnd
+			case m.walls.At(p):
+				return colourWall
+			case path[p]:
+				return colourPath
+			default:
+				return colourFloor
+			}
+		},
+	})
+}
+
+func (m *maze) dump(in *solver.Input, s solution) {
+	visited := map[grid.Point]bool{}
+	for _, c := range s {
+		visited[c] = true
+	}
+	in.Debugf("%s", m.walls.Render(func(p grid.Point, wall bool) string {
+		switch {
+		case p == m.startPos:
+			return "S"
+		case p == m.endPos:
+			return "E"
+		case visited[p]:
+			return "\u2588"
+		case wall:
+			return "|"
+		default:
+			return " "
+		}
+	}))
+}
+
+func newMaze(s string) (*maze, error) {
+	lines := scan.Lines(s)
+	var starts, ends []grid.Point
+	walls, err := grid.ParseLines(lines, "#.SE", func(pt grid.Point, ch rune) bool {
+		switch ch {
+		case 'S':
+			starts = append(starts, pt)
+		case 'E':
+			ends = append(ends, pt)
+		}
+		return ch == '#'
+	})
+	if err != nil {
+		return nil, err
+	}
+	ret := &maze{walls: walls}
+	if ret.startPos, err = grid.One(lines, starts, `start "S"`); err != nil {
+		return nil, err
+	}
+	if ret.endPos, err = grid.One(lines, ends, `end "E"`); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+type solution []grid.Point
+
+type saving struct {
+	pt1, pt2 grid.Point
+	saving   int
+}
+
+func (s solution) savings(maxCheats int) map[int]int {
+	var savings []saving
+	savingsBySaving := map[int]int{}
+	addSaving := func(p1, p2 grid.Point, saved int) {
+		savings = append(savings, saving{
+			pt1:    p1,
+			pt2:    p2,
+			saving: saved,
+		})
+		savingsBySaving[saved]++
+	}
+	for i, p := range s {
+		for j := i + 1; j < len(s); j++ {
+			candidate := s[j]
+			distance := j - i
+			cheatDistance := p.Distance(candidate)
+			if cheatDistance <= maxCheats && cheatDistance < distance {
+				addSaving(p, candidate, distance-cheatDistance)
+			}
+		}
+	}
+	/*
+		var keys []int
+		for k := range savingsBySaving {
+			keys = append(keys, k)
+			sort.Ints(keys)
+		}
+		for _, k := range keys {
+			log.Println(k, ":", savingsBySaving[k])
+		}
+	*/
+	return savingsBySaving
+}
+
+func cheatsSaving(in *solver.Input, maxCheats int) (any, error) {
+	m, err := newMaze(in.Text)
+	if err != nil {
+		return nil, err
+	}
+	s, err := m.solve()
+	if err != nil {
+		return nil, err
+	}
+	m.dump(in, s)
+	if in.Rendering() {
+		path := map[grid.Point]bool{}
+		for _, p := range s {
+			path[p] = true
+		}
+		m.frame(in, path)
+	}
+
+	least, err := in.Int("min", minSaving)
+	if err != nil {
+		return nil, err
+	}
+	counter := 0
+	for k, v := range s.savings(maxCheats) {
+		if k >= least {
+			counter += v
+		}
+	}
+	return counter, nil
+}
@@ -1,16 +1,20 @@
-package main
+package dec21
 
 import (
-	_ "embed"
 	"fmt"
-	"log"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
 )
 
-//go:embed input.txt
-var input string
+// part 2 needs 25 directional keypads which is out of reach when enumerating
+// every candidate sequence, so only part 1 is registered.
+func init() {
+	solver.Register(solver.Puzzle{Day: 21, Part1: part1})
+}
 
 func numericKeypadValueAt(row, col int) string {
 	if col < 0 || col > 2 {
@@ -198,7 +202,7 @@ func setupKeyboard(valueMap map[point]string, maxRows, maxCols int) map[pair][]s
 }
 
 // debugging stuff
-func printShortestPaths(shortestPaths map[pair][]string) {
+func printShortestPaths(in *solver.Input, shortestPaths map[pair][]string) {
 	var pairs []pair
 	for k := range shortestPaths {
 		pairs = append(pairs, k)
@@ -211,7 +215,7 @@ func printShortestPaths(shortestPaths map[pair][]string) {
 	})
 	for _, k := range pairs {
 		v := shortestPaths[k]
-		log.Println(k.a, k.b, strings.Join(v, ", "))
+		in.Tracef("%s %s %s", k.a, k.b, strings.Join(v, ", "))
 	}
 }
 
@@ -316,14 +320,14 @@ func toValues(str string) []string {
 	return values
 }
 
-func debugCandidates(cs []string) {
+func debugCandidates(in *solver.Input, cs []string) {
 	for _, c := range cs {
-		log.Printf("%3d: %s", len(c), c)
+		in.Tracef("%3d: %s", len(c), c)
 	}
 }
 
-func (z *puzzle) findShortestForCode(code string, iterations int) int {
-	log.Println("CODE:", code)
+func (z *puzzle) findShortestForCode(in *solver.Input, code string, iterations int) int {
+	in.Debugf("CODE: %s", code)
 	candidates := []string{code}
 	for i := 0; i < iterations; i++ {
 		var next []string
@@ -337,29 +341,51 @@ func (z *puzzle) findShortestForCode(code string, iterations int) int {
 		}
 		candidates = next
 		sortByLength(candidates)
-		log.Println("i=", i, ", total candidates=", len(candidates),
-			", shortest length", len(candidates[0]),
-			", longest length", len(candidates[len(candidates)-1]))
-		log.Println(candidates[0])
+		in.Tracef("i=%d, total candidates=%d, shortest length %d, longest length %d",
+			i, len(candidates), len(candidates[0]), len(candidates[len(candidates)-1]))
+		in.Tracef("%s", candidates[0])
 	}
 	return len(candidates[0])
 }
 
-func main() {
+// parseCode parses a door code such as "029A", returning its numeric part.
+func parseCode(l scan.Line) (int, error) {
+	sc := l.Scan()
+	digits, err := sc.Word("digit", "0123456789")
+	if err != nil {
+		return 0, err
+	}
+	if len(digits) != 3 {
+		return 0, l.ErrorAt(1, "3 digits")
+	}
+	if err := sc.Literal("A"); err != nil {
+		return 0, err
+	}
+	if err := sc.End(); err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(digits)
+}
+
+func part1(in *solver.Input) (any, error) {
 	puz := setup()
-	codes := strings.Split(strings.TrimSpace(input), "\n")
-	sum := 0
-	var vals []int
-	for _, code := range codes {
-		shortestSteps := puz.findShortestForCode(code, 3)
-		code0 := strings.TrimSuffix(code, "A")
-		n, err := strconv.Atoi(code0)
+	var codes []string
+	var nums []int
+	for _, l := range scan.Lines(in.Text) {
+		n, err := parseCode(l)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
-		val := shortestSteps * n
+		codes = append(codes, l.Text)
+		nums = append(nums, n)
+	}
+	sum := 0
+	var vals []int
+	for i, code := range codes {
+		shortestSteps := puz.findShortestForCode(in, code, 3)
+		val := shortestSteps * nums[i]
 		vals = append(vals, val)
 		sum += val
 	}
-	log.Println("SUM:", sum)
+	return sum, nil
 }
@@ -1,15 +1,25 @@
-package main
+package dec22
 
 import (
-	_ "embed"
-	"log"
 	"sort"
-	"strconv"
-	"strings"
+
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
 )
 
-//go:embed input.txt
-var input string
+func init() {
+	solver.Register(solver.Puzzle{
+		Day: 22,
+		Part1: func(in *solver.Input) (any, error) {
+			sum, _, err := analyze(in)
+			return sum, err
+		},
+		Part2: func(in *solver.Input) (any, error) {
+			_, best, err := analyze(in)
+			return best.sum, err
+		},
+	})
+}
 
 func mix(first, second int) int {
 	return first ^ second
@@ -26,14 +36,6 @@ func nextSecret(secret int) int {
 	return t3
 }
 
-func toNum(s string) int {
-	n, err := strconv.Atoi(s)
-	if err != nil {
-		panic(err)
-	}
-	return n
-}
-
 type change struct {
 	payoff int
 	delta  int
@@ -48,11 +50,24 @@ type partitionSum struct {
 	sum int
 }
 
-func main() {
-	lines := strings.Split(strings.TrimSpace(input), "\n")
+// analyze returns the sum of the 2000th secret for every buyer and the
+// partition that yields the most bananas.
+func analyze(in *solver.Input) (int, partitionSum, error) {
 	var nums []int
+	lines := scan.Lines(in.Text)
+	if len(lines) == 0 {
+		return 0, partitionSum{}, scan.EndOfInput(lines, "secret number")
+	}
 	for _, line := range lines {
-		nums = append(nums, toNum(line))
+		sc := line.Scan()
+		n, err := sc.Int()
+		if err != nil {
+			return 0, partitionSum{}, err
+		}
+		if err := sc.End(); err != nil {
+			return 0, partitionSum{}, err
+		}
+		nums = append(nums, n)
 	}
 	secret2K := func(s int) (int, []change) {
 		var ret []change
@@ -91,7 +106,6 @@ func main() {
 		sum += val
 		partitionsByBuyer = append(partitionsByBuyer, computePartitions(changes))
 	}
-	log.Println("SUM:", sum)
 
 	var psets []partitionSum
 	for p := range partitionSuperset {
@@ -104,5 +118,5 @@ func main() {
 	sort.Slice(psets, func(i, j int) bool {
 		return psets[i].sum > psets[j].sum
 	})
-	log.Println(psets[0])
+	return sum, psets[0], nil
 }
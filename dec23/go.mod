@@ -1,5 +0,0 @@
-module github.com/gotwarlost/aoc2024/dec23
-
-go 1.23.1
-
-require gonum.org/v1/gonum v0.15.1 // indirect
@@ -1,15 +1,33 @@
-package main
+package dec23
 
 import (
-	_ "embed"
-	"gonum.org/v1/gonum/stat/combin"
-	"log"
 	"sort"
 	"strings"
+
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+	"gonum.org/v1/gonum/stat/combin"
 )
 
-//go:embed input.txt
-var input string
+func init() {
+	solver.Register(solver.Puzzle{
+		Day: 23,
+		Part1: func(in *solver.Input) (any, error) {
+			pairs, err := parse(in)
+			if err != nil {
+				return nil, err
+			}
+			return part1(in, pairs), nil
+		},
+		Part2: func(in *solver.Input) (any, error) {
+			pairs, err := parse(in)
+			if err != nil {
+				return nil, err
+			}
+			return part2(in, pairs), nil
+		},
+	})
+}
 
 type pair struct {
 	a, b string
@@ -50,6 +68,9 @@ func ncr(candidates []string, r int, collaborators map[string]map[string]bool) [
 		return true
 	}
 	n := len(candidates)
+	if n < r {
+		return nil
+	}
 	combinations := combin.Combinations(n, r)
 	for _, combination := range combinations {
 		var people []string
@@ -77,7 +98,7 @@ func collaboratorSets(collaborators map[string]map[string]bool) [][]string {
 	return ret
 }
 
-func part1(pairs []pair) {
+func part1(in *solver.Input, pairs []pair) int {
 	trios := map[trio]bool{}
 	collaborators := toCollaboratorMap(pairs)
 	sets := collaboratorSets(collaborators)
@@ -87,17 +108,17 @@ func part1(pairs []pair) {
 			trios[trio{t[0], t[1], t[2]}] = true
 		}
 	}
-	log.Println("TRIPLES:", len(trios))
+	in.Diagnose("triples", len(trios))
 	var ret2 []trio
 	for t := range trios {
 		if strings.HasPrefix(t.a, "t") || strings.HasPrefix(t.b, "t") || strings.HasPrefix(t.c, "t") {
 			ret2 = append(ret2, t)
 		}
 	}
-	log.Println("T TRIPLES:", len(ret2))
+	return len(ret2)
 }
 
-func part2(pairs []pair) {
+func part2(in *solver.Input, pairs []pair) string {
 	collaborators := toCollaboratorMap(pairs)
 	sets := collaboratorSets(collaborators)
 	maxSizeFound := 0
@@ -118,17 +139,31 @@ outer:
 			r--
 		}
 	}
-	log.Println("MSF:", maxSizeFound)
-	log.Println(strings.Join(candidateWinner, ","))
+	in.Diagnose("size", maxSizeFound)
+	return strings.Join(candidateWinner, ",")
 }
 
-func main() {
-	lines := strings.Split(strings.TrimSpace(input), "\n")
+const letters = "abcdefghijklmnopqrstuvwxyz"
+
+func parse(in *solver.Input) ([]pair, error) {
 	var pairs []pair
-	for _, line := range lines {
-		parts := strings.Split(line, "-")
-		pairs = append(pairs, pair{parts[0], parts[1]})
+	for _, line := range scan.Lines(in.Text) {
+		sc := line.Scan()
+		a, err := sc.Word("computer name", letters)
+		if err != nil {
+			return nil, err
+		}
+		if err := sc.Literal("-"); err != nil {
+			return nil, err
+		}
+		b, err := sc.Word("computer name", letters)
+		if err != nil {
+			return nil, err
+		}
+		if err := sc.End(); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair{a, b})
 	}
-	part1(pairs)
-	part2(pairs)
+	return pairs, nil
 }
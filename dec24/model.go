@@ -1,4 +1,4 @@
-package main
+package dec24
 
 import (
 	"fmt"
@@ -29,16 +29,16 @@ func (o operator) apply(leftValue, rightValue int) int {
 
 func (o operator) String() string { return string(o) }
 
-func newOperator(s string) operator {
+func newOperator(s string) (operator, bool) {
 	switch s {
 	case string(XOR):
-		return XOR
+		return XOR, true
 	case string(AND):
-		return AND
+		return AND, true
 	case string(OR):
-		return OR
+		return OR, true
 	default:
-		panic("invalid operator:" + s)
+		return "", false
 	}
 }
 
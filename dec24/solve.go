@@ -1,23 +1,34 @@
-package main
+package dec24
 
 import (
-	_ "embed"
 	"fmt"
-	"log"
 	"sort"
-	"strconv"
 	"strings"
-)
 
-//go:embed input.txt
-var input string
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+)
 
-func toNum(s string) int {
-	n, err := strconv.Atoi(s)
-	if err != nil {
-		panic(err)
-	}
-	return n
+func init() {
+	solver.Register(solver.Puzzle{
+		Day: 24,
+		Part1: func(in *solver.Input) (any, error) {
+			puz, err := newPuzzle(in.Text)
+			if err != nil {
+				return nil, err
+			}
+			out, _ := puz.part1()
+			return out, nil
+		},
+		Part2: func(in *solver.Input) (any, error) {
+			puz, err := newPuzzle(in.Text)
+			if err != nil {
+				return nil, err
+			}
+			_, numZs := puz.part1()
+			return puz.part2(in, numZs), nil
+		},
+	})
 }
 
 type puzzle struct {
@@ -36,7 +47,71 @@ func makeExpr(in1 string, op operator, in2 string) string {
 	return fmt.Sprintf("%s %s %s", in1, op, in2)
 }
 
-func newPuzzle() *puzzle {
+const wireChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// parseInitial parses an initial value such as "x00: 1".
+func parseInitial(l scan.Line) (string, int, error) {
+	sc := l.Scan()
+	name, err := sc.Word("wire", wireChars)
+	if err != nil {
+		return "", 0, err
+	}
+	if err := sc.Literal(": "); err != nil {
+		return "", 0, err
+	}
+	bad := sc.Err("0 or 1")
+	v, err := sc.Int()
+	if err != nil {
+		return "", 0, err
+	}
+	if v != 0 && v != 1 {
+		return "", 0, bad
+	}
+	return name, v, sc.End()
+}
+
+// parsedGate is a gate along with errors to report if its inputs are not defined.
+type parsedGate struct {
+	gate
+	undefinedLeft, undefinedRight error
+}
+
+// parseGate parses a gate such as "x00 AND y00 -> z00".
+func parseGate(l scan.Line) (ret parsedGate, err error) {
+	sc := l.Scan()
+	ret.undefinedLeft = sc.Err("defined wire")
+	if ret.left, err = sc.Word("wire", wireChars); err != nil {
+		return ret, err
+	}
+	if err := sc.Literal(" "); err != nil {
+		return ret, err
+	}
+	bad := sc.Err("one of AND, OR or XOR")
+	op, err := sc.Word("operator", "ANDORX")
+	if err != nil {
+		return ret, bad
+	}
+	var ok bool
+	if ret.op, ok = newOperator(op); !ok {
+		return ret, bad
+	}
+	if err := sc.Literal(" "); err != nil {
+		return ret, err
+	}
+	ret.undefinedRight = sc.Err("defined wire")
+	if ret.right, err = sc.Word("wire", wireChars); err != nil {
+		return ret, err
+	}
+	if err := sc.Literal(" -> "); err != nil {
+		return ret, err
+	}
+	if ret.out, err = sc.Word("wire", wireChars); err != nil {
+		return ret, err
+	}
+	return ret, sc.End()
+}
+
+func newPuzzle(input string) (*puzzle, error) {
 	ret := &puzzle{
 		initialValues: map[string]int{},
 		values:        map[string]int{},
@@ -44,28 +119,44 @@ func newPuzzle() *puzzle {
 		gatesByExpr:   map[string]gate{},
 	}
 
-	lines := strings.Split(strings.TrimSpace(input), "\n")
-	assign := true
-	for _, l := range lines {
-		if assign {
-			if l == "" {
-				assign = false
-				continue
-			}
-			parts := strings.Split(l, ": ")
-			ret.initialValues[parts[0]] = toNum(parts[1])
-			continue
+	lines := scan.Lines(input)
+	blocks := scan.Blocks(lines)
+	if len(blocks) < 2 {
+		return nil, scan.EndOfInput(lines, "gates after a blank line")
+	}
+	if len(blocks) > 2 {
+		return nil, blocks[2][0].Error("end of input")
+	}
+	for _, l := range blocks[0] {
+		name, v, err := parseInitial(l)
+		if err != nil {
+			return nil, err
 		}
-		parts := strings.Split(l, " ")
-		if len(parts) != 5 {
-			panic("bad line: " + l)
+		ret.initialValues[name] = v
+	}
+	var parsed []parsedGate
+	for _, l := range blocks[1] {
+		pg, err := parseGate(l)
+		if err != nil {
+			return nil, err
 		}
-		g := gate{
-			left:  parts[0],
-			op:    newOperator(parts[1]),
-			right: parts[2],
-			out:   parts[4],
+		parsed = append(parsed, pg)
+	}
+	defined := map[string]bool{}
+	for name := range ret.initialValues {
+		defined[name] = true
+	}
+	for _, pg := range parsed {
+		defined[pg.out] = true
+	}
+	for _, pg := range parsed {
+		if !defined[pg.left] {
+			return nil, pg.undefinedLeft
+		}
+		if !defined[pg.right] {
+			return nil, pg.undefinedRight
 		}
+		g := pg.gate
 		if g.left > g.right {
 			g.left, g.right = g.right, g.left
 		}
@@ -89,7 +180,7 @@ func newPuzzle() *puzzle {
 	for _, vo := range ret.vars {
 		vo.init()
 	}
-	return ret
+	return ret, nil
 }
 
 func (z *puzzle) init() {
@@ -184,7 +275,7 @@ func (z *puzzle) getVarOps(name string) *varOps {
 	return vo
 }
 
-func (z *puzzle) newAdder(i int, carry string) (ret bitAdder) {
+func (z *puzzle) newAdder(in *solver.Input, i int, carry string) (ret bitAdder) {
 	//log.Println("adder:", i, carry)
 	defer func() {
 		//log.Printf("adder: %+v", ret)
@@ -198,11 +289,11 @@ func (z *puzzle) newAdder(i int, carry string) (ret bitAdder) {
 	}
 	b.outXOR, _ = z.findExprOutput(in1, XOR, in2)
 	if !z.getVarOps(b.outXOR).matchesSignature(sigOutXor) {
-		log.Println("out XOR signature mismatch for:", i, b.outXOR)
+		in.Debugf("out XOR signature mismatch for: %d %s", i, b.outXOR)
 	}
 	b.outAND, _ = z.findExprOutput(in1, AND, in2)
 	if !z.getVarOps(b.outAND).matchesSignature(sigOutAnd) {
-		log.Println("out AND signature mismatch for:", i, b.outAND)
+		in.Debugf("out AND signature mismatch for: %d %s", i, b.outAND)
 	}
 
 	// no input carry for the first
@@ -214,56 +305,54 @@ func (z *puzzle) newAdder(i int, carry string) (ret bitAdder) {
 		var ok bool
 		b.outSum, ok = z.findExprOutput(b.outXOR, XOR, b.inputCarry)
 		if !ok {
-			log.Printf("no out sum for %d: %s", i, makeExpr(b.outXOR, XOR, b.inputCarry))
+			in.Debugf("no out sum for %d: %s", i, makeExpr(b.outXOR, XOR, b.inputCarry))
 		} else {
 			if !z.getVarOps(b.outSum).matchesSignature(sigOutput) {
-				log.Printf("out sum signature mismatch for %d: %s", i, b.outSum)
+				in.Debugf("out sum signature mismatch for %d: %s", i, b.outSum)
 			}
 		}
 		b.carryAND, ok = z.findExprOutput(b.outXOR, AND, b.inputCarry)
 		if !ok {
-			log.Printf("no carry-and for %d: %s", i, makeExpr(b.outXOR, AND, b.inputCarry))
+			in.Debugf("no carry-and for %d: %s", i, makeExpr(b.outXOR, AND, b.inputCarry))
 		} else {
 			if !z.getVarOps(b.carryAND).matchesSignature(sigOutAnd) {
-				log.Printf("carry-and signature mismatch for %d: %s", i, b.carryAND)
+				in.Debugf("carry-and signature mismatch for %d: %s", i, b.carryAND)
 			}
 		}
 		b.outputCarry, ok = z.findExprOutput(b.outAND, OR, b.carryAND)
 		if !ok {
-			log.Printf("no output carry for %d: %s", i, makeExpr(b.outAND, OR, b.carryAND))
+			in.Debugf("no output carry for %d: %s", i, makeExpr(b.outAND, OR, b.carryAND))
 		} else {
 			if !z.getVarOps(b.outputCarry).matchesSignature(sigCarry) {
-				log.Printf("carry signature mismatch for %d: %s", i, b.outputCarry)
+				in.Debugf("carry signature mismatch for %d: %s", i, b.outputCarry)
 			}
 		}
 	}
 	if b.outSum != fmt.Sprintf("z%02d", i) {
-		log.Printf("unexpected output for %d: %s", i, b.outSum)
+		in.Debugf("unexpected output for %d: %s", i, b.outSum)
 	}
 	return b
 }
 
-func (z *puzzle) part2(numZs int) {
+// part2 logs the adders whose wiring does not match the expected signatures and
+// returns the bits in which the actual output differs from the sum of the inputs.
+// The swapped wires are found by reading the diagnostics, so a fixed input
+// returns 0.
+func (z *puzzle) part2(in *solver.Input, numZs int) int {
 	z.init()
 	x := z.valueFromBits("x", numZs-1)
 	y := z.valueFromBits("y", numZs-1)
 	actual := z.valueFromBits("z", numZs)
 	expected := x + y
 
-	log.Printf("Expected: %d %b", expected, expected)
-	log.Printf("  Actual: %d %b", actual, actual)
-	log.Printf("    diff: %14d %046b", actual^expected, actual^expected)
+	in.Debugf("Expected: %d %b", expected, expected)
+	in.Debugf("  Actual: %d %b", actual, actual)
+	in.Debugf("    diff: %14d %046b", actual^expected, actual^expected)
 
 	var carry string
 	for i := 0; i < numZs-1; i++ {
-		adder := z.newAdder(i, carry)
+		adder := z.newAdder(in, i, carry)
 		carry = adder.outputCarry
 	}
-}
-
-func main() {
-	puz := newPuzzle()
-	out, numZs := puz.part1()
-	log.Println("PART 1:", out)
-	puz.part2(numZs)
+	return actual ^ expected
 }
@@ -0,0 +1,86 @@
+package dec25
+
+import (
+	"github.com/gotwarlost/aoc2024/grid"
+	"github.com/gotwarlost/aoc2024/scan"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+// there is no second puzzle on the last day.
+func init() {
+	solver.Register(solver.Puzzle{Day: 25, Part1: part1})
+}
+
+// parseSchematic parses a lock or key schematic of 7 rows and 5 columns and returns
+// the height of each column.
+func parseSchematic(lines []scan.Line) (layout []int, isLock bool, err error) {
+	g, err := grid.ParseLines(lines, "#.", func(_ grid.Point, ch rune) bool {
+		return ch == '#'
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if g.Cols() != 5 {
+		return nil, false, lines[0].Error("5 columns")
+	}
+	if g.Rows() != 7 {
+		return nil, false, lines[0].Error("schematic of 7 rows")
+	}
+	full := func(row int) bool {
+		for col := 0; col < g.Cols(); col++ {
+			if !g.At(grid.Point{Row: row, Col: col}) {
+				return false
+			}
+		}
+		return true
+	}
+	switch {
+	case full(0):
+		isLock = true
+	case full(6):
+		isLock = false
+	default:
+		return nil, false, lines[0].Error(`lock or key with a full top or bottom row`)
+	}
+	layout = make([]int, 5)
+	for row := 1; row < 6; row++ {
+		for col := range layout {
+			if g.At(grid.Point{Row: row, Col: col}) {
+				layout[col]++
+			}
+		}
+	}
+	return layout, isLock, nil
+}
+
+func fits(lock, key []int) int {
+	for i := 0; i < len(lock); i++ {
+		if lock[i]+key[i] > 5 {
+			return 0
+		}
+	}
+	return 1
+}
+
+func part1(in *solver.Input) (any, error) {
+	var locks, keys [][]int
+	for _, block := range scan.Blocks(scan.Lines(in.Text)) {
+		layout, isLock, err := parseSchematic(block)
+		if err != nil {
+			return nil, err
+		}
+		if isLock {
+			locks = append(locks, layout)
+		} else {
+			keys = append(keys, layout)
+		}
+	}
+
+	combinations := 0
+	for lock := 0; lock < len(locks); lock++ {
+		for key := 0; key < len(keys); key++ {
+			combinations += fits(locks[lock], keys[key])
+		}
+	}
+	return combinations, nil
+}
@@ -0,0 +1,5 @@
+module github.com/gotwarlost/aoc2024
+
+go 1.23.1
+
+require gonum.org/v1/gonum v0.15.1
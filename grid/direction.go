@@ -0,0 +1,63 @@
+package grid
+
+// Direction is one of the four orthogonal directions. Directions are ordered
+// clockwise such that turning is simple arithmetic.
+type Direction int
+
+const (
+	Up Direction = iota
+	Right
+	Down
+	Left
+)
+
+// Directions are all directions, clockwise from Up.
+var Directions = []Direction{Up, Right, Down, Left}
+
+// Offset returns the offset to move one step in this direction.
+func (d Direction) Offset() Point {
+	return Offsets4[d]
+}
+
+// TurnRight returns the direction after turning 90 degrees clockwise.
+func (d Direction) TurnRight() Direction {
+	return (d + 1) % 4
+}
+
+// TurnLeft returns the direction after turning 90 degrees counter-clockwise.
+func (d Direction) TurnLeft() Direction {
+	return (d + 3) % 4
+}
+
+// Reverse returns the opposite direction.
+func (d Direction) Reverse() Direction {
+	return (d + 2) % 4
+}
+
+// Vertical returns true for the up and down directions.
+func (d Direction) Vertical() bool {
+	return d == Up || d == Down
+}
+
+const glyphs = "^>v<"
+
+// String returns the glyph used for the direction in puzzle inputs.
+func (d Direction) String() string {
+	return glyphs[d : d+1]
+}
+
+// ParseDirection returns the direction for one of the glyphs ^, >, v or <.
+func ParseDirection(ch rune) (Direction, bool) {
+	switch ch {
+	case '^':
+		return Up, true
+	case '>':
+		return Right, true
+	case 'v':
+		return Down, true
+	case '<':
+		return Left, true
+	default:
+		return 0, false
+	}
+}
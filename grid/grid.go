@@ -0,0 +1,233 @@
+// Package grid provides a two-dimensional grid of values that is typically parsed
+// from the character maps used by the puzzles, along with points and directions
+// to navigate it.
+package grid
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gotwarlost/aoc2024/scan"
+)
+
+// Point is a location in a grid.
+type Point struct {
+	Row, Col int
+}
+
+// Add returns the point offset by the supplied point.
+func (p Point) Add(o Point) Point {
+	return Point{p.Row + o.Row, p.Col + o.Col}
+}
+
+// Sub returns the offset from the supplied point to this one.
+func (p Point) Sub(o Point) Point {
+	return Point{p.Row - o.Row, p.Col - o.Col}
+}
+
+// Move returns the adjacent point in the supplied direction.
+func (p Point) Move(d Direction) Point {
+	return p.Add(d.Offset())
+}
+
+// Distance returns the manhattan distance between two points.
+func (p Point) Distance(o Point) int {
+	abs := func(x int) int {
+		if x < 0 {
+			return -x
+		}
+		return x
+	}
+	return abs(p.Row-o.Row) + abs(p.Col-o.Col)
+}
+
+var (
+	// Offsets4 are the offsets of the orthogonal neighbours of a point, clockwise from the top.
+	Offsets4 = []Point{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+	// Offsets8 are the offsets of all neighbours of a point including diagonals,
+	// clockwise from the top.
+	Offsets8 = []Point{{-1, 0}, {-1, 1}, {0, 1}, {1, 1}, {1, 0}, {1, -1}, {0, -1}, {-1, -1}}
+)
+
+// Grid is a rectangular grid of values.
+type Grid[T any] struct {
+	rows, cols int
+	cells      []T
+}
+
+// New returns a grid of the supplied size with zero values in every cell.
+func New[T any](rows, cols int) *Grid[T] {
+	return &Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}
+}
+
+// Parse parses a character map into a grid, calling the supplied function to convert
+// each character. Every character must be one of valid unless it is blank, every
+// line must have the same length and trailing blank lines are ignored.
+func Parse[T any](s string, valid string, conv func(p Point, ch rune) T) (*Grid[T], error) {
+	return ParseLines(scan.Lines(s), valid, conv)
+}
+
+// ParseLines is like Parse for lines that are a section of the input. Rows are
+// numbered from the first line supplied.
+func ParseLines[T any](lines []scan.Line, valid string, conv func(p Point, ch rune) T) (*Grid[T], error) {
+	if len(lines) == 0 {
+		return nil, scan.EndOfInput(lines, "grid")
+	}
+	cols := len([]rune(lines[0].Text))
+	if cols == 0 {
+		return nil, lines[0].ErrorAt(1, "grid row")
+	}
+	g := New[T](len(lines), cols)
+	for row, line := range lines {
+		chars := []rune(line.Text)
+		offset := 1 // byte column of the character for errors
+		for col, ch := range chars {
+			if col >= cols {
+				return nil, line.ErrorAt(offset, fmt.Sprintf("end of line after %d columns", cols))
+			}
+			if valid != "" && !strings.ContainsRune(valid, ch) {
+				return nil, line.CharAt(offset, fmt.Sprintf("one of %q", valid))
+			}
+			offset += utf8.RuneLen(ch)
+			p := Point{row, col}
+			g.Set(p, conv(p, ch))
+		}
+		if len(chars) < cols {
+			return nil, line.ErrorAt(len(line.Text)+1, fmt.Sprintf("%d columns", cols))
+		}
+	}
+	return g, nil
+}
+
+// One returns the only point of those found when parsing the supplied lines,
+// typically the positions of a marker such as a start point. It returns an error
+// describing the expected marker if there are none or pointing at the second one
+// if there are several.
+func One(lines []scan.Line, found []Point, expected string) (Point, error) {
+	switch len(found) {
+	case 0:
+		return Point{}, scan.EndOfInput(lines, expected)
+	case 1:
+		return found[0], nil
+	default:
+		p := found[1]
+		return Point{}, lines[p.Row].CharAt(p.Col+1, "a single "+expected)
+	}
+}
+
+// Rune is a conversion function for Parse that keeps the characters as-is.
+func Rune(_ Point, ch rune) rune {
+	return ch
+}
+
+// Rows returns the number of rows in the grid.
+func (g *Grid[T]) Rows() int { return g.rows }
+
+// Cols returns the number of columns in the grid.
+func (g *Grid[T]) Cols() int { return g.cols }
+
+// In returns true if the point is within the bounds of the grid.
+func (g *Grid[T]) In(p Point) bool {
+	return p.Row >= 0 && p.Row < g.rows && p.Col >= 0 && p.Col < g.cols
+}
+
+// At returns the value at the supplied point which must be in the grid.
+func (g *Grid[T]) At(p Point) T {
+	if !g.In(p) {
+		panic(fmt.Sprintf("point %v outside %dx%d grid", p, g.rows, g.cols))
+	}
+	return g.cells[p.Row*g.cols+p.Col]
+}
+
+// Get returns the value at the supplied point and true, or the zero value and
+// false if the point is not in the grid.
+func (g *Grid[T]) Get(p Point) (T, bool) {
+	if !g.In(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Row*g.cols+p.Col], true
+}
+
+// Set sets the value at the supplied point which must be in the grid.
+func (g *Grid[T]) Set(p Point, v T) {
+	if !g.In(p) {
+		panic(fmt.Sprintf("point %v outside %dx%d grid", p, g.rows, g.cols))
+	}
+	g.cells[p.Row*g.cols+p.Col] = v
+}
+
+// Clone returns a copy of the grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	cells := make([]T, len(g.cells))
+	copy(cells, g.cells)
+	return &Grid[T]{rows: g.rows, cols: g.cols, cells: cells}
+}
+
+// Points returns every point in the grid in row-major order.
+func (g *Grid[T]) Points() []Point {
+	ret := make([]Point, 0, len(g.cells))
+	for row := 0; row < g.rows; row++ {
+		for col := 0; col < g.cols; col++ {
+			ret = append(ret, Point{row, col})
+		}
+	}
+	return ret
+}
+
+func (g *Grid[T]) neighbours(p Point, offsets []Point) []Point {
+	var ret []Point
+	for _, o := range offsets {
+		n := p.Add(o)
+		if g.In(n) {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+// Neighbours4 returns the orthogonal neighbours of a point that are in the grid.
+func (g *Grid[T]) Neighbours4(p Point) []Point {
+	return g.neighbours(p, Offsets4)
+}
+
+// Neighbours8 returns all neighbours of a point, including diagonals, that are in the grid.
+func (g *Grid[T]) Neighbours8(p Point) []Point {
+	return g.neighbours(p, Offsets8)
+}
+
+// Render returns a textual representation of the grid using the supplied
+// function to render each cell.
+func (g *Grid[T]) Render(cell func(p Point, v T) string) string {
+	var b strings.Builder
+	for row := 0; row < g.rows; row++ {
+		for col := 0; col < g.cols; col++ {
+			p := Point{row, col}
+			b.WriteString(cell(p, g.At(p)))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Find returns the first point in row-major order that holds the supplied value.
+func Find[T comparable](g *Grid[T], v T) (Point, bool) {
+	for i, c := range g.cells {
+		if c == v {
+			return Point{i / g.cols, i % g.cols}, true
+		}
+	}
+	return Point{}, false
+}
+
+// FindAll returns every point that holds the supplied value in row-major order.
+func FindAll[T comparable](g *Grid[T], v T) []Point {
+	var ret []Point
+	for i, c := range g.cells {
+		if c == v {
+			ret = append(ret, Point{i / g.cols, i % g.cols})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,99 @@
+package inputs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the puzzle site for this year.
+	DefaultBaseURL = "https://adventofcode.com/2024"
+	// DefaultInterval is the minimum time between requests to the puzzle site.
+	DefaultInterval = 5 * time.Second
+
+	userAgent = "github.com/gotwarlost/aoc2024"
+)
+
+// Doer sends HTTP requests, it is implemented by *http.Client.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Fetcher downloads inputs from the puzzle site using a session cookie.
+// Requests are spaced out by at least the configured interval.
+type Fetcher struct {
+	BaseURL  string        // defaults to DefaultBaseURL
+	Session  string        // value of the session cookie
+	Client   Doer          // defaults to http.DefaultClient
+	Interval time.Duration // defaults to DefaultInterval, negative to disable rate limiting
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// wait blocks until the next request is allowed.
+func (f *Fetcher) wait(ctx context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	interval := f.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+	if !f.last.IsZero() && interval > 0 {
+		if d := time.Until(f.last.Add(interval)); d > 0 {
+			t := time.NewTimer(d)
+			defer t.Stop()
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	f.last = time.Now()
+	return nil
+}
+
+// Fetch downloads the input for a day.
+func (f *Fetcher) Fetch(ctx context.Context, day int) (string, error) {
+	if f.Session == "" {
+		return "", fmt.Errorf("fetch day %d: no session cookie", day)
+	}
+	base := f.BaseURL
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if err := f.wait(ctx); err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/day/%d/input", strings.TrimSuffix(base, "/"), day)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: f.Session})
+	req.Header.Set("User-Agent", userAgent)
+	res, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch day %d: %v", day, err)
+	}
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("fetch day %d: %v", day, err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetch day %d: %s: %s", day, res.Status, strings.TrimSpace(string(b)))
+	}
+	return string(b), nil
+}
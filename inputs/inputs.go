@@ -0,0 +1,133 @@
+// Package inputs finds the puzzle input for a day. Inputs are looked up in a local
+// cache directory, then in the inputs embedded in the binary and are finally
+// fetched over HTTP and stored in the cache.
+package inputs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+var embedded = map[int]string{}
+
+// Embed registers the input embedded for a day. Days call this from a file that
+// is excluded when building with the noembed tag.
+func Embed(day int, text string) {
+	embedded[day] = text
+}
+
+// Embedded returns the embedded input for a day.
+func Embedded(day int) (string, bool) {
+	s, ok := embedded[day]
+	return s, ok
+}
+
+// Cache is a directory that stores one input file per day.
+type Cache struct {
+	Dir string
+}
+
+// DefaultDir returns the default cache directory under the user's cache directory.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc2024"), nil
+}
+
+func (c *Cache) file(day int) string {
+	return filepath.Join(c.Dir, fmt.Sprintf("dec%02d.txt", day))
+}
+
+// Get returns the cached input for a day and false if it isn't in the cache.
+func (c *Cache) Get(day int) (string, bool, error) {
+	b, err := os.ReadFile(c.file(day))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return string(b), true, nil
+}
+
+// Put stores the input for a day in the cache.
+func (c *Cache) Put(day int, text string) error {
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.file(day), []byte(text), 0o644)
+}
+
+// Import copies the input.txt files of the day directories under root into the
+// cache and returns the days that were imported.
+func (c *Cache) Import(root string) ([]int, error) {
+	var ret []int
+	for day := 1; day <= 25; day++ {
+		b, err := os.ReadFile(filepath.Join(root, fmt.Sprintf("dec%02d", day), "input.txt"))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return ret, err
+		}
+		if err := c.Put(day, string(b)); err != nil {
+			return ret, err
+		}
+		ret = append(ret, day)
+	}
+	return ret, nil
+}
+
+// Loader finds inputs using the cache, embedded inputs and the fetcher in that order.
+type Loader struct {
+	Cache   *Cache   // nil to disable caching
+	Fetcher *Fetcher // nil to disable fetching
+}
+
+// Available returns true if the input for a day is cached or embedded, so that
+// it can be loaded without fetching it.
+func (l *Loader) Available(day int) bool {
+	if _, ok := Embedded(day); ok {
+		return true
+	}
+	if l.Cache == nil {
+		return false
+	}
+	_, ok, err := l.Cache.Get(day)
+	return ok && err == nil
+}
+
+// Load returns the input for a day.
+func (l *Loader) Load(ctx context.Context, day int) (string, error) {
+	if l.Cache != nil {
+		s, ok, err := l.Cache.Get(day)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return s, nil
+		}
+	}
+	if s, ok := Embedded(day); ok {
+		return s, nil
+	}
+	if l.Fetcher == nil {
+		return "", fmt.Errorf("no input for day %d and fetching is disabled", day)
+	}
+	s, err := l.Fetcher.Fetch(ctx, day)
+	if err != nil {
+		return "", err
+	}
+	if l.Cache != nil {
+		if err := l.Cache.Put(day, s); err != nil {
+			return "", err
+		}
+	}
+	return s, nil
+}
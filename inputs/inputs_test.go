@@ -0,0 +1,176 @@
+package inputs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// site is a stand-in for the puzzle site that records the requests it receives.
+type site struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []*http.Request
+	times    []time.Time
+}
+
+func newSite(t *testing.T, handler http.HandlerFunc) *site {
+	s := &site{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r)
+		s.times = append(s.times, time.Now())
+		s.mu.Unlock()
+		handler(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *site) fetcher() *Fetcher {
+	return &Fetcher{BaseURL: s.URL + "/2024", Session: "secret", Client: s.Client(), Interval: -1}
+}
+
+func serve(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}
+}
+
+func TestFetchSendsSessionToDayURL(t *testing.T) {
+	s := newSite(t, serve("1 2\n3 4\n"))
+	got, err := s.fetcher().Fetch(context.Background(), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "1 2\n3 4\n" {
+		t.Errorf("got input %q", got)
+	}
+	if len(s.requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(s.requests))
+	}
+	r := s.requests[0]
+	if r.URL.Path != "/2024/day/7/input" {
+		t.Errorf("got path %s, want /2024/day/7/input", r.URL.Path)
+	}
+	c, err := r.Cookie("session")
+	if err != nil || c.Value != "secret" {
+		t.Errorf("got session cookie %v, %v, want secret", c, err)
+	}
+	if ua := r.Header.Get("User-Agent"); ua != userAgent {
+		t.Errorf("got user agent %q, want %q", ua, userAgent)
+	}
+}
+
+func TestFetchReturnsErrorForBadStatus(t *testing.T) {
+	s := newSite(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "Please log in", http.StatusBadRequest)
+	})
+	_, err := s.fetcher().Fetch(context.Background(), 3)
+	if err == nil {
+		t.Fatal("got no error")
+	}
+	if want := "fetch day 3: 400 Bad Request: Please log in"; err.Error() != want {
+		t.Errorf("got error %q, want %q", err, want)
+	}
+}
+
+func TestFetchRequiresSession(t *testing.T) {
+	s := newSite(t, serve("input"))
+	f := s.fetcher()
+	f.Session = ""
+	if _, err := f.Fetch(context.Background(), 1); err == nil {
+		t.Error("got no error")
+	}
+	if len(s.requests) != 0 {
+		t.Errorf("got %d requests, want none", len(s.requests))
+	}
+}
+
+func TestLoadFetchesAndCachesOnMiss(t *testing.T) {
+	s := newSite(t, serve("fetched\n"))
+	cache := &Cache{Dir: t.TempDir()}
+	l := &Loader{Cache: cache, Fetcher: s.fetcher()}
+	for range 2 {
+		got, err := l.Load(context.Background(), 26)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "fetched\n" {
+			t.Errorf("got input %q", got)
+		}
+	}
+	if len(s.requests) != 1 {
+		t.Errorf("got %d requests, want 1", len(s.requests))
+	}
+	got, ok, err := cache.Get(26)
+	if err != nil || !ok || got != "fetched\n" {
+		t.Errorf("got cached %q, %t, %v, want the fetched input", got, ok, err)
+	}
+}
+
+func TestLoadPrefersCacheToEmbedded(t *testing.T) {
+	const day = 26
+	Embed(day, "embedded")
+	t.Cleanup(func() { delete(embedded, day) })
+	cache := &Cache{Dir: t.TempDir()}
+	l := &Loader{Cache: cache}
+
+	got, err := l.Load(context.Background(), day)
+	if err != nil || got != "embedded" {
+		t.Errorf("got %q, %v before caching, want the embedded input", got, err)
+	}
+	if err := cache.Put(day, "cached"); err != nil {
+		t.Fatal(err)
+	}
+	got, err = l.Load(context.Background(), day)
+	if err != nil || got != "cached" {
+		t.Errorf("got %q, %v after caching, want the cached input", got, err)
+	}
+}
+
+func TestLoadWithoutFetcher(t *testing.T) {
+	l := &Loader{Cache: &Cache{Dir: t.TempDir()}}
+	_, err := l.Load(context.Background(), 26)
+	if err == nil || !strings.Contains(err.Error(), "fetching is disabled") {
+		t.Errorf("got error %v, want fetching is disabled", err)
+	}
+}
+
+func TestFetchSpacesRequestsByInterval(t *testing.T) {
+	const interval = 50 * time.Millisecond
+	s := newSite(t, serve("input"))
+	f := s.fetcher()
+	f.Interval = interval
+	for day := 1; day <= 3; day++ {
+		if _, err := f.Fetch(context.Background(), day); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 1; i < len(s.times); i++ {
+		if d := s.times[i].Sub(s.times[i-1]); d < interval {
+			t.Errorf("request %d came %v after the previous one, want at least %v", i+1, d, interval)
+		}
+	}
+}
+
+func TestFetchWaitHonoursContext(t *testing.T) {
+	s := newSite(t, serve("input"))
+	f := s.fetcher()
+	f.Interval = time.Hour
+	if _, err := f.Fetch(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := f.Fetch(ctx, 2); err == nil {
+		t.Error("got no error for a request within the interval")
+	}
+	if len(s.requests) != 1 {
+		t.Errorf("got %d requests, want 1", len(s.requests))
+	}
+}
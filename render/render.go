@@ -0,0 +1,129 @@
+// Package render draws grid states as images so that simulations can be exported
+// as PNG frames or animated GIFs.
+package render
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/grid"
+)
+
+// Frame is a snapshot of a simulation on a grid. Each cell is drawn with the
+// colour at the palette index returned for it.
+type Frame struct {
+	Rows, Cols int
+	Palette    color.Palette // the default colours for the indices returned by Cell
+	Cell       func(p grid.Point) uint8
+}
+
+// Sink receives the frames of a simulation.
+type Sink interface {
+	Frame(f Frame)
+}
+
+// Options control how frames are drawn.
+type Options struct {
+	CellSize int           // width and height of a cell in pixels, defaults to 4
+	Palette  color.Palette // overrides the frame palette for every non-nil entry
+	Delay    int           // delay between GIF frames in 100ths of a second
+	Every    int           // only keep every nth frame, defaults to every frame
+}
+
+func (o Options) cellSize() int {
+	if o.CellSize <= 0 {
+		return 4
+	}
+	return o.CellSize
+}
+
+// keep returns true if the frame with the supplied 0-based sequence number is kept.
+func (o Options) keep(n int) bool {
+	return o.Every <= 1 || n%o.Every == 0
+}
+
+func (o Options) palette(p color.Palette) color.Palette {
+	ret := make(color.Palette, max(len(p), len(o.Palette)))
+	for i := range ret {
+		switch {
+		case i < len(o.Palette) && o.Palette[i] != nil:
+			ret[i] = o.Palette[i]
+		case i < len(p):
+			ret[i] = p[i]
+		default:
+			ret[i] = color.Black
+		}
+	}
+	return ret
+}
+
+// Image draws the frame.
+func (o Options) Image(f Frame) *image.Paletted {
+	size := o.cellSize()
+	img := image.NewPaletted(image.Rect(0, 0, f.Cols*size, f.Rows*size), o.palette(f.Palette))
+	for row := 0; row < f.Rows; row++ {
+		for col := 0; col < f.Cols; col++ {
+			index := f.Cell(grid.Point{Row: row, Col: col})
+			for y := row * size; y < (row+1)*size; y++ {
+				start := img.PixOffset(col*size, y)
+				for x := 0; x < size; x++ {
+					img.Pix[start+x] = index
+				}
+			}
+		}
+	}
+	return img
+}
+
+// ParsePalette parses a comma separated list of hex colours such as "000000,,ff8800".
+// Blank entries are nil so that the default colour at that index is kept.
+func ParsePalette(s string) (color.Palette, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var ret color.Palette
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimPrefix(strings.TrimSpace(part), "#")
+		if part == "" {
+			ret = append(ret, nil)
+			continue
+		}
+		v, err := strconv.ParseUint(part, 16, 32)
+		if err != nil || len(part) != 6 {
+			return nil, fmt.Errorf("invalid colour %q, want rrggbb", part)
+		}
+		ret = append(ret, color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff})
+	}
+	return ret, nil
+}
+
+// Hues returns a palette of n bright colours with evenly spaced hues, for values
+// that have no natural colour such as the names of regions.
+func Hues(n int) color.Palette {
+	ret := make(color.Palette, n)
+	for i := range ret {
+		h := float64(i) * 6 / float64(n)
+		x := uint8(255 * (1 - math.Abs(math.Mod(h, 2)-1)))
+		var r, g, b uint8
+		switch int(h) {
+		case 0:
+			r, g = 255, x
+		case 1:
+			r, g = x, 255
+		case 2:
+			g, b = 255, x
+		case 3:
+			g, b = x, 255
+		case 4:
+			r, b = x, 255
+		default:
+			r, b = 255, x
+		}
+		ret[i] = color.RGBA{R: r, G: g, B: b, A: 0xff}
+	}
+	return ret
+}
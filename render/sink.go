@@ -0,0 +1,129 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// GIF collects frames into an animated GIF.
+type GIF struct {
+	opts   Options
+	seen   int
+	frames []*image.Paletted
+}
+
+// NewGIF returns a sink that collects frames for an animated GIF.
+func NewGIF(opts Options) *GIF {
+	return &GIF{opts: opts}
+}
+
+// Frame adds a frame to the animation.
+func (g *GIF) Frame(f Frame) {
+	defer func() { g.seen++ }()
+	if !g.opts.keep(g.seen) {
+		return
+	}
+	g.frames = append(g.frames, g.opts.Image(f))
+}
+
+// Len returns the number of frames collected.
+func (g *GIF) Len() int {
+	return len(g.frames)
+}
+
+// Encode writes the animation, which loops forever.
+func (g *GIF) Encode(w io.Writer) error {
+	if len(g.frames) == 0 {
+		return errors.New("no frames to encode")
+	}
+	anim := &gif.GIF{Image: g.frames, Delay: make([]int, len(g.frames))}
+	for i := range anim.Delay {
+		anim.Delay[i] = g.opts.Delay
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// PNGs writes every frame as a numbered PNG file in a directory.
+type PNGs struct {
+	dir     string
+	opts    Options
+	seen    int
+	written int
+	err     error
+}
+
+// NewPNGs returns a sink that writes frames to files named frame-00000.png
+// onwards in the supplied directory, which is created if needed.
+func NewPNGs(dir string, opts Options) *PNGs {
+	return &PNGs{dir: dir, opts: opts}
+}
+
+// Frame writes the frame unless an earlier frame failed.
+func (p *PNGs) Frame(f Frame) {
+	defer func() { p.seen++ }()
+	if p.err != nil || !p.opts.keep(p.seen) {
+		return
+	}
+	if p.written == 0 {
+		if p.err = os.MkdirAll(p.dir, 0o755); p.err != nil {
+			return
+		}
+	}
+	p.err = writePNG(filepath.Join(p.dir, fmt.Sprintf("frame-%05d.png", p.written)), p.opts.Image(f))
+	p.written++
+}
+
+// Len returns the number of frames written.
+func (p *PNGs) Len() int {
+	return p.written
+}
+
+// Err returns the first error writing a frame.
+func (p *PNGs) Err() error {
+	return p.err
+}
+
+func writePNG(file string, img image.Image) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Last keeps the last frame of a simulation, which is typically its final state.
+// The frame is drawn when it is encoded so its cells must remain valid until then.
+type Last struct {
+	frame *Frame
+}
+
+// Frame replaces the frame kept.
+func (l *Last) Frame(f Frame) {
+	l.frame = &f
+}
+
+// Image draws the last frame, returning nil if there wasn't one.
+func (l *Last) Image(opts Options) *image.Paletted {
+	if l.frame == nil {
+		return nil
+	}
+	return opts.Image(*l.frame)
+}
+
+// Size returns the number of rows and columns of the last frame.
+func (l *Last) Size() (rows, cols int) {
+	if l.frame == nil {
+		return 0, 0
+	}
+	return l.frame.Rows, l.frame.Cols
+}
@@ -0,0 +1,186 @@
+// Package scan provides helpers to parse puzzle input that report errors with
+// the line and column of the offending text.
+package scan
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Error is a parse error at a position in the input.
+type Error struct {
+	Line     int    // 1-based line number
+	Col      int    // 1-based byte column, 0 when the error applies to the whole line
+	Len      int    // length of the offending text
+	Expected string // what was expected, e.g. "number"
+	Found    string // what was found instead, ready to print
+	Text     string // the text of the line
+}
+
+func (e *Error) Error() string {
+	if e.Col == 0 {
+		return fmt.Sprintf("line %d: expected %s, found %s", e.Line, e.Expected, e.Found)
+	}
+	return fmt.Sprintf("line %d, column %d: expected %s, found %s", e.Line, e.Col, e.Expected, e.Found)
+}
+
+// Snippet returns the line with the offending text underlined by carets.
+func (e *Error) Snippet() string {
+	prefix := fmt.Sprintf("%5d | ", e.Line)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s\n", prefix, e.Text)
+	fmt.Fprintf(&b, "%*s | ", 5, "")
+	if e.Col > 0 {
+		b.WriteString(strings.Repeat(" ", e.Col-1))
+		b.WriteString(strings.Repeat("^", max(e.Len, 1)))
+	} else {
+		b.WriteString(strings.Repeat("^", max(len(e.Text), 1)))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// Line is a line of input with its 1-based line number.
+type Line struct {
+	Num    int
+	Text   string
+	Offset int // byte offset of the start of the line in the input
+}
+
+// Lines splits the text into lines, ignoring trailing blank lines and carriage
+// returns. Leading lines are kept so that line numbers match the input.
+func Lines(s string) []Line {
+	s = strings.TrimRightFunc(s, unicode.IsSpace)
+	if s == "" {
+		return nil
+	}
+	var ret []Line
+	offset := 0
+	for i, text := range strings.Split(s, "\n") {
+		ret = append(ret, Line{Num: i + 1, Text: strings.TrimSuffix(text, "\r"), Offset: offset})
+		offset += len(text) + 1
+	}
+	return ret
+}
+
+// maxLineSize is the length of the longest line that Read accepts.
+const maxLineSize = 16 << 20
+
+// Read calls fn for every line read from r without holding more than a line in
+// memory. Lines are numbered and trimmed of carriage returns as for Lines, but
+// trailing blank lines are passed to fn.
+func Read(r io.Reader, fn func(l Line) error) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), maxLineSize)
+	// count the bytes consumed by each line, including the line ending, so that
+	// offsets are those of the input whether lines end with \n or \r\n
+	offset, next := 0, 0
+	sc.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		if token != nil {
+			offset, next = next, next+advance
+		}
+		return advance, token, err
+	})
+	for n := 1; sc.Scan(); n++ {
+		if err := fn(Line{Num: n, Text: strings.TrimSuffix(sc.Text(), "\r"), Offset: offset}); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+// Blocks splits lines into groups separated by blank lines.
+func Blocks(lines []Line) [][]Line {
+	var ret [][]Line
+	var current []Line
+	for _, l := range lines {
+		if l.Text == "" {
+			if current != nil {
+				ret = append(ret, current)
+			}
+			current = nil
+			continue
+		}
+		current = append(current, l)
+	}
+	if current != nil {
+		ret = append(ret, current)
+	}
+	return ret
+}
+
+// token returns the text starting at the byte offset up to the next space.
+func token(s string, offset int) string {
+	s = s[offset:]
+	if i := strings.IndexFunc(s, unicode.IsSpace); i >= 0 {
+		s = s[:i]
+	}
+	return s
+}
+
+// ErrorAt returns an error for the token at the 1-based column of the line.
+func (l Line) ErrorAt(col int, expected string) *Error {
+	e := &Error{Line: l.Num, Col: col, Expected: expected, Text: l.Text, Found: "end of line"}
+	if col-1 < len(l.Text) {
+		tok := token(l.Text, col-1)
+		if tok == "" {
+			tok = l.Text[col-1 : col]
+		}
+		e.Len = len(tok)
+		e.Found = fmt.Sprintf("%q", tok)
+	}
+	return e
+}
+
+// Span returns an error for the text of the supplied length at the 1-based column
+// of the line.
+func (l Line) Span(col, n int, expected string) *Error {
+	text := l.Text[col-1 : col-1+n]
+	return &Error{Line: l.Num, Col: col, Len: n, Expected: expected, Found: fmt.Sprintf("%q", text), Text: l.Text}
+}
+
+// CharAt returns an error for the single character at the 1-based column of the line.
+func (l Line) CharAt(col int, expected string) *Error {
+	e := l.ErrorAt(col, expected)
+	if col-1 < len(l.Text) {
+		r, size := utf8.DecodeRuneInString(l.Text[col-1:])
+		e.Len = size
+		e.Found = fmt.Sprintf("%q", r)
+	}
+	return e
+}
+
+// Error returns an error that applies to the whole line.
+func (l Line) Error(expected string) *Error {
+	return &Error{Line: l.Num, Expected: expected, Found: fmt.Sprintf("%q", l.Text), Text: l.Text}
+}
+
+// EndOfInput returns an error for input that stops before something expected,
+// positioned on the line following the supplied lines.
+func EndOfInput(lines []Line, expected string) *Error {
+	n := 1
+	if len(lines) > 0 {
+		n = lines[len(lines)-1].Num + 1
+	}
+	return &Error{Line: n, Expected: expected, Found: "end of input"}
+}
+
+// At returns an error for the text of the supplied length at a byte offset of the
+// input, such as a match found by a regular expression over the whole input.
+func At(text string, offset, n int, expected string) *Error {
+	num := strings.Count(text[:offset], "\n") + 1
+	start := strings.LastIndex(text[:offset], "\n") + 1
+	end := strings.IndexByte(text[offset:], '\n')
+	if end < 0 {
+		end = len(text)
+	} else {
+		end += offset
+	}
+	l := Line{Num: num, Text: strings.TrimSuffix(text[start:end], "\r")}
+	return l.Span(offset-start+1, n, expected)
+}
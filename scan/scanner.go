@@ -0,0 +1,124 @@
+package scan
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Scanner reads tokens from a line from left to right.
+type Scanner struct {
+	line Line
+	pos  int // byte offset of the next token
+}
+
+// Scan returns a scanner positioned at the start of the line.
+func (l Line) Scan() *Scanner {
+	return &Scanner{line: l}
+}
+
+// Err returns an error at the current position.
+func (s *Scanner) Err(expected string) *Error {
+	return s.line.ErrorAt(s.pos+1, expected)
+}
+
+// Done returns true when the whole line has been read.
+func (s *Scanner) Done() bool {
+	return s.pos >= len(s.line.Text)
+}
+
+// End returns an error unless the whole line has been read.
+func (s *Scanner) End() error {
+	if !s.Done() {
+		return s.Err("end of line")
+	}
+	return nil
+}
+
+// Spaces skips any spaces and tabs and returns true if there were some.
+func (s *Scanner) Spaces() bool {
+	start := s.pos
+	for s.pos < len(s.line.Text) && (s.line.Text[s.pos] == ' ' || s.line.Text[s.pos] == '\t') {
+		s.pos++
+	}
+	return s.pos > start
+}
+
+// Peek returns true if the literal is next.
+func (s *Scanner) Peek(lit string) bool {
+	return strings.HasPrefix(s.line.Text[s.pos:], lit)
+}
+
+// Literal reads the supplied text.
+func (s *Scanner) Literal(lit string) error {
+	if !s.Peek(lit) {
+		return s.Err(fmt.Sprintf("%q", lit))
+	}
+	s.pos += len(lit)
+	return nil
+}
+
+// Int reads a decimal integer with an optional sign.
+func (s *Scanner) Int() (int, error) {
+	text := s.line.Text
+	end := s.pos
+	if end < len(text) && (text[end] == '-' || text[end] == '+') {
+		end++
+	}
+	digits := end
+	for end < len(text) && text[end] >= '0' && text[end] <= '9' {
+		end++
+	}
+	if end == digits {
+		return 0, s.Err("number")
+	}
+	n, err := strconv.Atoi(text[s.pos:end])
+	if err != nil {
+		return 0, s.line.Span(s.pos+1, end-s.pos, "number in range")
+	}
+	s.pos = end
+	return n, nil
+}
+
+// Ints reads integers separated by the supplied separator up to the end of the
+// line. A separator of a single space matches any run of spaces.
+func (s *Scanner) Ints(sep string) ([]int, error) {
+	var ret []int
+	for {
+		n, err := s.Int()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, n)
+		if s.Done() {
+			return ret, nil
+		}
+		if err := s.Literal(sep); err != nil {
+			return nil, err
+		}
+		if sep == " " {
+			s.Spaces()
+		}
+	}
+}
+
+// Word reads one or more characters from the supplied set, describing it as
+// expected in errors.
+func (s *Scanner) Word(expected string, chars string) (string, error) {
+	text := s.line.Text
+	end := s.pos
+	for end < len(text) && strings.IndexByte(chars, text[end]) >= 0 {
+		end++
+	}
+	if end == s.pos {
+		return "", s.Err(expected)
+	}
+	ret := text[s.pos:end]
+	s.pos = end
+	return ret, nil
+}
+
+// Ints parses a line of integers separated by the supplied separator.
+func (l Line) Ints(sep string) ([]int, error) {
+	return l.Scan().Ints(sep)
+}
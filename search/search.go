@@ -0,0 +1,201 @@
+// Package search implements shortest path searches over implicit graphs whose
+// states are identified by comparable keys.
+package search
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// Edge is a transition to another state with its cost.
+type Edge[S comparable] struct {
+	To   S
+	Cost int
+}
+
+// Problem describes a weighted search.
+type Problem[S comparable] struct {
+	Start []S                 // start states, all with a cost of 0
+	Next  func(s S) []Edge[S] // returns the transitions out of a state, costs must not be negative
+	Goal  func(s S) bool      // returns true for goal states, nil to explore every reachable state
+	// Heuristic optionally returns a lower bound of the cost from the state to a goal,
+	// turning the search into A*. It must be consistent for the result to be optimal.
+	Heuristic func(s S) int
+}
+
+// Result is the result of a search.
+type Result[S comparable] struct {
+	Found bool // true if a goal was reached
+	Cost  int  // the cost of the cheapest path to a goal
+	Goals []S  // every goal state reached at the optimal cost, in the order found
+	start []S
+	dist  map[S]int
+	prev  map[S][]S
+}
+
+func newResult[S comparable](start []S) *Result[S] {
+	r := &Result[S]{start: start, dist: map[S]int{}, prev: map[S][]S{}}
+	for _, s := range start {
+		r.dist[s] = 0
+	}
+	return r
+}
+
+// Dist returns the cost of the cheapest path found to the supplied state.
+func (r *Result[S]) Dist(s S) (int, bool) {
+	d, ok := r.dist[s]
+	return d, ok
+}
+
+// Predecessors returns every state from which the supplied state is reached
+// at its optimal cost.
+func (r *Result[S]) Predecessors(s S) []S {
+	return r.prev[s]
+}
+
+// Path returns a cheapest path from a start state to the first goal, inclusive of both.
+func (r *Result[S]) Path() []S {
+	if !r.Found {
+		return nil
+	}
+	return r.PathTo(r.Goals[0])
+}
+
+// PathTo returns a cheapest path from a start state to the supplied state, or
+// nil if it wasn't reached.
+func (r *Result[S]) PathTo(s S) []S {
+	if _, ok := r.dist[s]; !ok {
+		return nil
+	}
+	ret := []S{s}
+	for {
+		prev := r.prev[s]
+		if len(prev) == 0 {
+			break
+		}
+		s = prev[0]
+		ret = append(ret, s)
+	}
+	slices.Reverse(ret)
+	return ret
+}
+
+// OnOptimalPaths returns every state that lies on any cheapest path to a goal.
+func (r *Result[S]) OnOptimalPaths() map[S]bool {
+	ret := map[S]bool{}
+	pending := slices.Clone(r.Goals)
+	for len(pending) > 0 {
+		s := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if ret[s] {
+			continue
+		}
+		ret[s] = true
+		pending = append(pending, r.prev[s]...)
+	}
+	return ret
+}
+
+// visit records a path to the state at the supplied cost, returning true if
+// it is cheaper than any seen so far.
+func (r *Result[S]) visit(from, to S, cost int) bool {
+	current, ok := r.dist[to]
+	switch {
+	case !ok || cost < current:
+		r.dist[to] = cost
+		r.prev[to] = []S{from}
+		return true
+	case cost == current && !slices.Contains(r.prev[to], from):
+		r.prev[to] = append(r.prev[to], from)
+	}
+	return false
+}
+
+type item[S comparable] struct {
+	state    S
+	cost     int
+	priority int
+}
+
+type queue[S comparable] struct {
+	items []item[S]
+}
+
+func (q *queue[S]) Len() int           { return len(q.items) }
+func (q *queue[S]) Less(i, j int) bool { return q.items[i].priority < q.items[j].priority }
+func (q *queue[S]) Swap(i, j int)      { q.items[i], q.items[j] = q.items[j], q.items[i] }
+func (q *queue[S]) Push(x any)         { q.items = append(q.items, x.(item[S])) }
+func (q *queue[S]) Pop() any {
+	ret := q.items[q.Len()-1]
+	q.items = q.items[:q.Len()-1]
+	return ret
+}
+
+// Dijkstra runs a cheapest path search for the problem, using A* when a heuristic
+// is supplied. The search continues after the first goal is reached until every
+// goal state at the same cost has been found.
+func Dijkstra[S comparable](p Problem[S]) *Result[S] {
+	r := newResult(p.Start)
+	h := func(s S) int {
+		if p.Heuristic == nil {
+			return 0
+		}
+		return p.Heuristic(s)
+	}
+	q := &queue[S]{}
+	for _, s := range p.Start {
+		heap.Push(q, item[S]{state: s, priority: h(s)})
+	}
+	done := map[S]bool{}
+	for q.Len() > 0 {
+		head := heap.Pop(q).(item[S])
+		if r.Found && head.priority > r.Cost {
+			break
+		}
+		if done[head.state] || head.cost > r.dist[head.state] {
+			continue
+		}
+		done[head.state] = true
+		if p.Goal != nil && p.Goal(head.state) {
+			r.Found = true
+			r.Cost = head.cost
+			r.Goals = append(r.Goals, head.state)
+			continue
+		}
+		for _, e := range p.Next(head.state) {
+			cost := head.cost + e.Cost
+			if r.visit(head.state, e.To, cost) {
+				heap.Push(q, item[S]{state: e.To, cost: cost, priority: cost + h(e.To)})
+			}
+		}
+	}
+	return r
+}
+
+// BFS runs a breadth first search where every transition has a cost of 1, which
+// is cheaper than Dijkstra for unweighted graphs.
+func BFS[S comparable](start S, next func(s S) []S, goal func(s S) bool) *Result[S] {
+	r := newResult([]S{start})
+	frontier := []S{start}
+	for depth := 0; len(frontier) > 0; depth++ {
+		var following []S
+		for _, s := range frontier {
+			if goal != nil && goal(s) {
+				r.Found = true
+				r.Cost = depth
+				r.Goals = append(r.Goals, s)
+				continue
+			}
+			for _, n := range next(s) {
+				if r.visit(s, n, depth+1) {
+					following = append(following, n)
+				}
+			}
+		}
+		if r.Found {
+			break
+		}
+		frontier = following
+	}
+	return r
+}
@@ -0,0 +1,84 @@
+package solver
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/metrics"
+	"time"
+)
+
+// Stats are the resources used when running a solution.
+type Stats struct {
+	Duration time.Duration `json:"duration_ns"` // wall time
+	Allocs   uint64        `json:"allocs"`      // number of heap allocations
+	Bytes    uint64        `json:"bytes"`       // bytes allocated on the heap
+	PeakHeap uint64        `json:"peak_heap"`   // peak size of live heap objects, sampled periodically
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("time=%v allocs=%d alloc=%s peak=%s", s.Duration, s.Allocs, byteSize(s.Bytes), byteSize(s.PeakHeap))
+}
+
+func byteSize(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+const heapObjects = "/memory/classes/heap/objects:bytes"
+
+// sampleInterval is how often the heap is sampled for peak usage.
+const sampleInterval = time.Millisecond
+
+func heapInUse(s []metrics.Sample) uint64 {
+	metrics.Read(s)
+	return s[0].Value.Uint64()
+}
+
+// Measure runs the supplied function and returns the resources it used. It runs
+// a garbage collection first so that the peak heap is not skewed by earlier work.
+func Measure(fn func()) Stats {
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	sample := []metrics.Sample{{Name: heapObjects}}
+	peak := heapInUse(sample)
+	stop := make(chan struct{})
+	sampled := make(chan uint64)
+	go func() {
+		highest := peak
+		t := time.NewTicker(sampleInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				highest = max(highest, heapInUse(sample))
+			case <-stop:
+				sampled <- max(highest, heapInUse(sample))
+				return
+			}
+		}
+	}()
+
+	start := time.Now()
+	fn()
+	elapsed := time.Since(start)
+	close(stop)
+	peak = <-sampled
+
+	runtime.ReadMemStats(&after)
+	return Stats{
+		Duration: elapsed,
+		Allocs:   after.Mallocs - before.Mallocs,
+		Bytes:    after.TotalAlloc - before.TotalAlloc,
+		PeakHeap: peak,
+	}
+}
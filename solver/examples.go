@@ -0,0 +1,79 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Example is an entry in the expected.json file of a day. It names an input file
+// in the same directory and the expected answers, a blank answer is not checked.
+// Malformed inputs instead have the error expected from every part of the day.
+type Example struct {
+	Input  string            `json:"input"`
+	Params map[string]string `json:"params,omitempty"`
+	Part1  string            `json:"part1,omitempty"`
+	Part2  string            `json:"part2,omitempty"`
+	Error  string            `json:"error,omitempty"`
+}
+
+// Expected returns the expected answer for the part.
+func (e Example) Expected(part int) string {
+	if part == 1 {
+		return e.Part1
+	}
+	return e.Part2
+}
+
+// Checks returns true if the example checks the part of the puzzle.
+func (e Example) Checks(p *Puzzle, part int) bool {
+	return p.Part(part) != nil && (e.Error != "" || e.Expected(part) != "")
+}
+
+// Check runs a part against the example input and returns a description of the
+// failure, or a blank string if it produced the expected answer or error.
+func (e Example) Check(p *Puzzle, part int, text string) string {
+	in := &Input{Text: text, Params: e.Params}
+	ret, err := p.Solve(part, in)
+	if e.Error != "" {
+		switch {
+		case err == nil:
+			return fmt.Sprintf("got %v, want error %s", ret.Answer, e.Error)
+		case err.Error() != e.Error:
+			return fmt.Sprintf("got error %v, want %s", err, e.Error)
+		}
+		return ""
+	}
+	if err != nil {
+		return err.Error()
+	}
+	if got, want := fmt.Sprint(ret.Answer), e.Expected(part); got != want {
+		return fmt.Sprintf("got %s, want %s", got, want)
+	}
+	return ""
+}
+
+// DayDir returns the directory of a day under the repository root.
+func DayDir(root string, day int) string {
+	return filepath.Join(root, fmt.Sprintf("dec%02d", day))
+}
+
+// ReadExamples returns the examples for a day, or nil if it doesn't have any.
+func ReadExamples(root string, day int) ([]Example, error) {
+	file := filepath.Join(DayDir(root, day), "expected.json")
+	b, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var ret []Example
+	if err := json.Unmarshal(b, &ret); err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	return ret, nil
+}
@@ -0,0 +1,41 @@
+package solver_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/gotwarlost/aoc2024/days"
+	"github.com/gotwarlost/aoc2024/solver"
+)
+
+// TestExamples checks the answers and errors listed in the expected.json file of
+// every day.
+func TestExamples(t *testing.T) {
+	root := ".."
+	for _, day := range solver.Days() {
+		p, _ := solver.Get(day)
+		examples, err := solver.ReadExamples(root, day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, e := range examples {
+			b, err := os.ReadFile(filepath.Join(solver.DayDir(root, day), e.Input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, part := range []int{1, 2} {
+				if !e.Checks(p, part) {
+					continue
+				}
+				name := fmt.Sprintf("dec%02d/%d-%s/part%d", day, i, e.Input, part)
+				t.Run(name, func(t *testing.T) {
+					if msg := e.Check(p, part, string(b)); msg != "" {
+						t.Error(msg)
+					}
+				})
+			}
+		}
+	}
+}
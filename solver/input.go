@@ -0,0 +1,121 @@
+package solver
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gotwarlost/aoc2024/render"
+)
+
+// Input is the puzzle input passed to a solution along with any parameters
+// that change how the input is interpreted (e.g. grid sizes that differ between
+// the examples and the real puzzle). It also carries the stream for debug output,
+// the sink for rendered frames and collects diagnostics that are reported along
+// with the answer.
+type Input struct {
+	Text        string
+	File        string // the file holding the input for puzzles that stream it, Text is blank if set
+	Params      map[string]string
+	Verbosity   int         // 0 for no debug output, 1 for debug output, 2 to also trace
+	Debug       io.Writer   // where debug output is written, stderr if nil
+	Frames      render.Sink // receives the frames of simulations, nil if they are not rendered
+	diagnostics map[string]any
+	overlay     any
+}
+
+// NewInput returns an input for the supplied text with no parameters.
+func NewInput(text string) *Input {
+	return &Input{Text: text, Params: map[string]string{}}
+}
+
+// Open returns a reader for the input, which reads the file if there is one
+// and the text otherwise.
+func (in *Input) Open() (io.ReadCloser, error) {
+	if in.File != "" {
+		return os.Open(in.File)
+	}
+	return io.NopCloser(strings.NewReader(in.Text)), nil
+}
+
+// Int returns the value of the named integer parameter or the default
+// if it has not been set.
+func (in *Input) Int(name string, def int) (int, error) {
+	s, ok := in.Params[name]
+	if !ok {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q, want an integer", name, s)
+	}
+	return n, nil
+}
+
+// Bool returns the value of the named boolean parameter or the default
+// if it has not been set.
+func (in *Input) Bool(name string, def bool) (bool, error) {
+	s, ok := in.Params[name]
+	if !ok {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q, want true or false", name, s)
+	}
+	return b, nil
+}
+
+func (in *Input) logf(level int, format string, args ...any) {
+	if in.Verbosity < level {
+		return
+	}
+	w := in.Debug
+	if w == nil {
+		w = os.Stderr
+	}
+	s := fmt.Sprintf(format, args...)
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+	_, _ = io.WriteString(w, s)
+}
+
+// Debugf writes debug output such as grid dumps when the verbosity is at least 1.
+func (in *Input) Debugf(format string, args ...any) {
+	in.logf(1, format, args...)
+}
+
+// Tracef writes detailed progress output when the verbosity is at least 2.
+func (in *Input) Tracef(format string, args ...any) {
+	in.logf(2, format, args...)
+}
+
+// Rendering returns true if the frames of a simulation are wanted. Solutions check
+// this before building frames that would otherwise be discarded.
+func (in *Input) Rendering() bool {
+	return in.Frames != nil
+}
+
+// Frame sends a frame of a simulation to the sink, if there is one.
+func (in *Input) Frame(f render.Frame) {
+	if in.Frames != nil {
+		in.Frames.Frame(f)
+	}
+}
+
+// Diagnose records a named value that is reported along with the answer.
+func (in *Input) Diagnose(name string, value any) {
+	if in.diagnostics == nil {
+		in.diagnostics = map[string]any{}
+	}
+	in.diagnostics[name] = value
+}
+
+// Overlay records a map of what the solution found, which is returned with the
+// answer so that it can be written out as JSON.
+func (in *Input) Overlay(v any) {
+	in.overlay = v
+}
@@ -0,0 +1,14 @@
+package solver
+
+import "time"
+
+// Result is the outcome of running one part of a puzzle.
+type Result struct {
+	Day         int            `json:"day"`
+	Part        int            `json:"part"`
+	Answer      any            `json:"answer"`
+	Duration    time.Duration  `json:"duration_ns"`
+	Diagnostics map[string]any `json:"diagnostics,omitempty"`
+	Stats       *Stats         `json:"stats,omitempty"` // only set when benchmarking
+	Overlay     any            `json:"-"`               // the map recorded by the solution, nil if there is none
+}
@@ -0,0 +1,93 @@
+// Package solver provides a registry of puzzle solutions, one per day.
+package solver
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Func solves one part of a puzzle and returns the answer, or an error if the
+// input cannot be parsed.
+type Func func(in *Input) (any, error)
+
+// Puzzle is the solution for a single day.
+type Puzzle struct {
+	Day    int
+	Part1  Func
+	Part2  Func     // nil if not solved
+	Stream bool     // the parts read the input with Input.Open, so large inputs need not fit in memory
+	Files  []string // params naming files that the parts read, which the dashboard refuses
+}
+
+// Part returns the function for the supplied part number, or nil if
+// there isn't one.
+func (p *Puzzle) Part(n int) Func {
+	switch n {
+	case 1:
+		return p.Part1
+	case 2:
+		return p.Part2
+	default:
+		return nil
+	}
+}
+
+// Solve runs the supplied part against the input, returning an error
+// if there is no such part, the solution fails or it panics.
+func (p *Puzzle) Solve(part int, in *Input) (ret Result, err error) {
+	fn := p.Part(part)
+	if fn == nil {
+		return ret, fmt.Errorf("day %d has no part %d", p.Day, part)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	in.diagnostics = nil
+	in.overlay = nil
+	start := time.Now()
+	answer, err := fn(in)
+	if err != nil {
+		return ret, err
+	}
+	return Result{
+		Day:         p.Day,
+		Part:        part,
+		Answer:      answer,
+		Duration:    time.Since(start),
+		Diagnostics: in.diagnostics,
+		Overlay:     in.overlay,
+	}, nil
+}
+
+var puzzles = map[int]*Puzzle{}
+
+// Register registers the solution for a day. It is meant to be called from
+// the init function of the package implementing the day.
+func Register(p Puzzle) {
+	if p.Day < 1 || p.Day > 25 {
+		panic(fmt.Sprintf("invalid day: %d", p.Day))
+	}
+	if _, ok := puzzles[p.Day]; ok {
+		panic(fmt.Sprintf("day %d registered twice", p.Day))
+	}
+	puzzles[p.Day] = &p
+}
+
+// Get returns the solution for the supplied day.
+func Get(day int) (*Puzzle, bool) {
+	p, ok := puzzles[day]
+	return p, ok
+}
+
+// Days returns the registered days in order.
+func Days() []int {
+	var ret []int
+	for d := range puzzles {
+		ret = append(ret, d)
+	}
+	sort.Ints(ret)
+	return ret
+}